@@ -6,6 +6,7 @@ import (
 	"compress/gzip"
 	"database/sql/driver"
 	"encoding/csv"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -39,6 +40,7 @@ type CsvDataSource struct {
 	rc       io.ReadCloser
 	rowct    uint64
 	headers  []string
+	first    []string // first row when there is no header row, not yet returned
 	colindex map[string]int
 	indexCol int
 	filter   expr.Node
@@ -47,6 +49,13 @@ type CsvDataSource struct {
 // NewCsvSource reader assumes we are getting first row as headers
 // - optionally may be gzipped
 func NewCsvSource(table string, indexCol int, ior io.Reader, exit <-chan bool) (*CsvDataSource, error) {
+	return NewCsvSourceHeader(table, indexCol, ior, exit, true)
+}
+
+// NewCsvSourceHeader reader, if hasHeader is false the first row is
+// data not headers and the columns are named col0, col1, ...
+// - optionally may be gzipped
+func NewCsvSourceHeader(table string, indexCol int, ior io.Reader, exit <-chan bool, hasHeader bool) (*CsvDataSource, error) {
 
 	m := CsvDataSource{table: table, indexCol: indexCol}
 	if rc, ok := ior.(io.ReadCloser); ok {
@@ -85,6 +94,10 @@ func NewCsvSource(table string, indexCol int, ior io.Reader, exit <-chan bool) (
 		u.Warnf("err csv %v", err)
 		return nil, err
 	}
+	if !hasHeader {
+		m.first = headers
+		headers = CsvColumnNames(len(m.first))
+	}
 	//u.Debugf("headers: %v", headers)
 	m.headers = headers
 	m.colindex = make(map[string]int, len(headers))
@@ -98,6 +111,16 @@ func NewCsvSource(table string, indexCol int, ior io.Reader, exit <-chan bool) (
 	return &m, nil
 }
 
+// CsvColumnNames are the synthetic column names col0, col1, ... for
+// a csv file of ct columns without a header row
+func CsvColumnNames(ct int) []string {
+	names := make([]string, ct)
+	for i := range names {
+		names[i] = fmt.Sprintf("col%d", i)
+	}
+	return names
+}
+
 func (m *CsvDataSource) Init()                            {}
 func (m *CsvDataSource) Setup(*schema.SchemaSource) error { return nil }
 func (m *CsvDataSource) Tables() []string                 { return []string{m.table} }
@@ -146,13 +169,24 @@ func (m *CsvDataSource) Close() error {
 	return nil
 }
 
+// read the next row, the first row of a file without headers was
+// already read so is returned first
+func (m *CsvDataSource) read() ([]string, error) {
+	if m.first != nil {
+		row := m.first
+		m.first = nil
+		return row, nil
+	}
+	return m.csvr.Read()
+}
+
 func (m *CsvDataSource) Next() schema.Message {
 	select {
 	case <-m.exit:
 		return nil
 	default:
 		for {
-			row, err := m.csvr.Read()
+			row, err := m.read()
 
 			if err != nil {
 				if err == io.EOF {
@@ -62,3 +62,21 @@ func TestCsvDataSource(t *testing.T) {
 	assert.Equal(t, nil, err)
 	csvIn.Close()
 }
+
+func TestCsvDataSourceNoHeader(t *testing.T) {
+	sr := strings.NewReader("9Ip1aKbeZe2njCDM,fishing,82\nhT2impsOPUREcVPc,swimming,12\n")
+	csvIn, err := datasource.NewCsvSourceHeader("user.csv", 0, sr, make(<-chan bool, 1), false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"col0", "col1", "col2"}, csvIn.Columns())
+
+	rows := make([]*datasource.SqlDriverMessageMap, 0)
+	for msg := csvIn.Next(); msg != nil; msg = csvIn.Next() {
+		rows = append(rows, msg.(*datasource.SqlDriverMessageMap))
+	}
+	// first row is data not headers
+	assert.Equal(t, 2, len(rows))
+	v, ok := rows[0].Get("col1")
+	assert.True(t, ok)
+	assert.Equal(t, "fishing", v.ToString())
+	csvIn.Close()
+}
@@ -22,6 +22,9 @@ type FileTable struct {
 	Table       string
 	PartialPath string
 	FileCount   int
+	// Files of this table listed by the FileSource Lister, without a
+	// Lister the files are listed from the store as the table is read
+	Files []*FileInfo
 }
 
 // FileInfo describes a single file
@@ -44,13 +47,15 @@ type FileInfo struct {
 	Partition   int            // which partition
 	Size        int            // Content-Length size in bytes
 	AppendCols  []driver.Value // Additional Column info extracted from file name/folder path
+	Columns     []FileColumn   // Columns inferred from contents, see InferSchema
 }
 
 // FileReader file info and access to file to supply to ScannerMakers
 type FileReader struct {
 	*FileInfo
-	F    io.ReadCloser // Actual file reader
-	Exit chan bool     // exit channel to shutdown reader
+	F        io.ReadCloser // Actual file reader
+	Exit     chan bool     // exit channel to shutdown reader
+	NoHeader bool          // first row is data, not column names, of a csv file
 }
 
 func (m *FileInfo) String() string {
@@ -85,10 +90,18 @@ func (m *FileInfo) updated() time.Time {
 // Convert a cloudstorage object to a File.  Interpret the table name
 // for given full file path.
 func FileInfoFromCloudObject(path string, obj cloudstorage.Object) *FileInfo {
+	fi := FileInfoFromName(path, obj.Name())
+	fi.obj = obj
+	return fi
+}
+
+// FileInfoFromName creates a File from the full name (key) of a file as
+// listed from a store.  Interpret the table name for given full file path.
+func FileInfoFromName(path, name string) *FileInfo {
 
-	fi := &FileInfo{Name: obj.Name(), obj: obj, Path: path}
+	fi := &FileInfo{Name: name, Path: path}
 
-	fi.Table = TableFromFileAndPath(path, obj.Name())
+	fi.Table = TableFromFileAndPath(path, name)
 
 	// Get the part of path as follows
 	//  /path/partialpath/filename.csv
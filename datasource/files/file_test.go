@@ -1,10 +1,19 @@
 package files
 
 import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"strings"
 	"testing"
 
 	u "github.com/araddon/gou"
+	"github.com/lytics/cloudstorage"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
+
+	"github.com/araddon/qlbridge/schema"
+	"github.com/araddon/qlbridge/value"
 )
 
 var _ = u.EMPTY
@@ -30,3 +39,228 @@ func TestFileInfo(t *testing.T) {
 	assert.NotEqual(t, "", fi.String())
 
 }
+
+type memLister struct {
+	keys []string
+}
+
+func (m *memLister) List(path string) ([]string, error) {
+	names := make([]string, 0)
+	for _, k := range m.keys {
+		if strings.HasPrefix(k, path) {
+			names = append(names, k)
+		}
+	}
+	return names, nil
+}
+
+func TestFileLister(t *testing.T) {
+
+	ml := &memLister{keys: []string{
+		"baseball/tables/players/2017.csv",
+		"baseball/tables/players/2018.csv",
+		"baseball/tables/teams.csv",
+		"baseball/tables/players/partition1/2017.csv",
+		"baseball/tables/",
+		"other/tables/users.csv",
+	}}
+	fis, err := FileInfosFromLister(ml, "baseball/", "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(fis))
+	assert.Equal(t, "baseball/tables/players/2017.csv", fis[0].Name)
+	assert.Equal(t, "players", fis[0].Table)
+	assert.Equal(t, "tables/players", fis[0].PartialPath)
+	assert.Equal(t, "players", fis[1].Table)
+	assert.Equal(t, "teams", fis[2].Table)
+	assert.Equal(t, "baseball/", fis[2].Path)
+
+	fis, err = FileInfosFromLister(NewLocalFileLister("tables"), "baseball", "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, len(fis))
+	assert.Equal(t, "baseball/appearances/appearances.csv", fis[0].Name)
+	assert.Equal(t, "appearances", fis[0].Table)
+
+	_, err = FileInfosFromLister(NewLocalFileLister("tables"), "not-a-folder", "")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestFileSourceLister(t *testing.T) {
+
+	m := NewFileSource()
+	m.path = "baseball/"
+	m.Lister = &memLister{keys: []string{
+		"baseball/tables/players/2017.csv",
+		"baseball/tables/players/2018.csv",
+		"baseball/tables/teams.csv",
+		"baseball/tables/players/_SUCCESS",
+	}}
+	err := m.findTables()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []string{"players", "teams"}, m.Tables())
+	assert.Equal(t, "tables/players", m.tables["players"].PartialPath)
+	assert.Equal(t, 2, m.tables["players"].FileCount)
+	assert.Equal(t, "baseball/tables/players/2018.csv", m.tables["players"].Files[1].Name)
+}
+
+func TestFilePagerLister(t *testing.T) {
+
+	// files are listed by the Lister, and read from the store
+	store, err := cloudstorage.NewStore(&localFilesConfig)
+	assert.Equal(t, nil, err)
+	m := NewFileSource()
+	m.path = "baseball"
+	m.store = store
+	m.Lister = &memLister{keys: []string{
+		"baseball/appearances/appearances.csv",
+		"baseball/appearances/_SUCCESS",
+	}}
+	assert.Equal(t, nil, m.findTables())
+	assert.Equal(t, []string{"appearances"}, m.Tables())
+
+	pg := NewFilePager("appearances", m)
+	pg.RunFetcher()
+	fr, err := pg.NextFile()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "baseball/appearances/appearances.csv", fr.Name)
+	fr.F.Close()
+	_, err = pg.NextFile()
+	assert.Equal(t, iterator.Done, err)
+}
+
+func TestFileListerFilter(t *testing.T) {
+
+	assert.Equal(t, true, IncludeFile("", "baseball/", "baseball/tables/players/2017.csv"))
+	assert.Equal(t, true, IncludeFile("tables/*/*.csv", "baseball/", "baseball/tables/players/2017.csv"))
+	assert.Equal(t, false, IncludeFile("tables/*/*.csv", "baseball/", "baseball/tables/players/2017.json"))
+	assert.Equal(t, false, IncludeFile("tables/*/*.csv", "baseball/", "baseball/tables/teams.csv"))
+	assert.Equal(t, false, IncludeFile("", "baseball/", "baseball/tables/players/_SUCCESS"))
+	assert.Equal(t, false, IncludeFile("", "baseball/", "baseball/tables/players/.2017.csv.tmp"))
+	assert.Equal(t, false, IncludeFile("", "baseball/", "baseball/tables/_temporary/2017.csv"))
+	assert.Equal(t, false, IncludeFile("", "baseball/", "baseball/tables/"))
+	// a hidden or _ folder in the path prefix itself is fine
+	assert.Equal(t, true, IncludeFile("", "_data/", "_data/players.csv"))
+
+	ml := &memLister{keys: []string{
+		"baseball/tables/players/2017.csv",
+		"baseball/tables/players/_SUCCESS",
+		"baseball/tables/players/.2018.csv.crc",
+		"baseball/tables/teams/2017.csv",
+		"baseball/tables/teams/2017.json",
+		"baseball/tables/teams/_SUCCESS",
+		"baseball/tables/_temporary/part-0000.csv",
+	}}
+	fis, err := FileInfosFromLister(ml, "baseball/", "tables/*/*.csv")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(fis))
+	tables := make(map[string]int)
+	for _, fi := range fis {
+		tables[fi.Table]++
+	}
+	assert.Equal(t, 1, tables["players"])
+	assert.Equal(t, 1, tables["teams"])
+
+	// without a pattern, markers are still skipped
+	fis, err = FileInfosFromLister(ml, "baseball/", "")
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(fis))
+}
+
+func TestInferSchema(t *testing.T) {
+
+	data := `user_id,name,score,active,created,notes
+1,bob,1.5,true,2017-01-02T15:04:05Z,
+2,alice,20,false,2017-02-02T15:04:05Z,x
+3,,7,true,2017-03-02T15:04:05Z,5
+`
+	cols, err := InferSchema(strings.NewReader(data), FormatCSV, true)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 6, len(cols))
+	assert.Equal(t, "user_id", cols[0].Name)
+	assert.Equal(t, value.IntType, cols[0].Type)
+	assert.Equal(t, value.StringType, cols[1].Type)
+	// ints and floats mixed are numbers
+	assert.Equal(t, value.NumberType, cols[2].Type)
+	assert.Equal(t, value.BoolType, cols[3].Type)
+	assert.Equal(t, value.TimeType, cols[4].Type)
+	// mixed types are strings
+	assert.Equal(t, value.StringType, cols[5].Type)
+
+	// header only, no sample of values
+	cols, err = InferSchema(strings.NewReader("a,b\n"), FormatCSV, true)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(cols))
+	assert.Equal(t, value.StringType, cols[1].Type)
+
+	_, err = InferSchema(strings.NewReader(data), FormatJSON, true)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestInferSchemaNoHeader(t *testing.T) {
+
+	data := `1,bob,1.5
+2,alice,20
+`
+	cols, err := InferSchema(strings.NewReader(data), FormatCSV, false)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, len(cols))
+	assert.Equal(t, "col0", cols[0].Name)
+	assert.Equal(t, "col2", cols[2].Name)
+	// first row is sampled as data
+	assert.Equal(t, value.IntType, cols[0].Type)
+	assert.Equal(t, value.StringType, cols[1].Type)
+	assert.Equal(t, value.NumberType, cols[2].Type)
+}
+
+func TestSampleColumns(t *testing.T) {
+
+	data := "user_id,name\n1,bob\n2,alice\n"
+
+	fi := &FileInfo{Name: "users.csv"}
+	f := sampleColumns(fi, ioutil.NopCloser(strings.NewReader(data)), true)
+	assert.Equal(t, 2, len(fi.Columns))
+	assert.Equal(t, "user_id", fi.Columns[0].Name)
+	assert.Equal(t, value.IntType, fi.Columns[0].Type)
+	// the sampled rows are read again
+	all, err := ioutil.ReadAll(f)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, data, string(all))
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte(data))
+	gw.Close()
+	zipped := gz.String()
+
+	fi = &FileInfo{Name: "users.csv.gz"}
+	f = sampleColumns(fi, ioutil.NopCloser(strings.NewReader(zipped)), true)
+	assert.Equal(t, 2, len(fi.Columns))
+	assert.Equal(t, "name", fi.Columns[1].Name)
+	all, err = ioutil.ReadAll(f)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, zipped, string(all))
+}
+
+func TestCsvScannerNoHeader(t *testing.T) {
+
+	// the one registered csv handler reads files of sources with and
+	// without header rows, the reader says which
+	fh, ok := scannerGet("csv")
+	assert.True(t, ok)
+
+	scan := func(noHeader bool) []string {
+		fr := &FileReader{
+			FileInfo: &FileInfo{Name: "users.csv", Table: "users"},
+			F:        ioutil.NopCloser(strings.NewReader("1,bob\n2,alice\n")),
+			Exit:     make(chan bool),
+			NoHeader: noHeader,
+		}
+		s, err := fh.Scanner(nil, fr)
+		assert.Equal(t, nil, err)
+		cols, ok := s.(schema.ConnColumns)
+		assert.True(t, ok)
+		return cols.Columns()
+	}
+	assert.Equal(t, []string{"col0", "col1"}, scan(true))
+	assert.Equal(t, []string{"1", "bob"}, scan(false))
+	assert.Equal(t, []string{"col0", "col1"}, scan(true))
+}
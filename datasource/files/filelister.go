@@ -0,0 +1,108 @@
+package files
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// Ensure our local lister implements FileLister
+	_ FileLister = (*LocalFileLister)(nil)
+)
+
+// FileLister lists the names (keys) of the files under a path prefix of
+// an object store, such as an S3 bucket or a local folder.  Names are
+// slash separated, and include the path prefix.
+//
+//    List("baseball/") -> ["baseball/tables/players.csv", ...]
+type FileLister interface {
+	List(path string) ([]string, error)
+}
+
+// LocalFileLister lists files on the local filesystem below Root, the
+// names it returns are relative to Root.
+type LocalFileLister struct {
+	Root string
+}
+
+// NewLocalFileLister creates a lister for files below root folder.
+func NewLocalFileLister(root string) *LocalFileLister {
+	return &LocalFileLister{Root: root}
+}
+
+// List the files below path, in lexical order.
+func (m *LocalFileLister) List(path string) ([]string, error) {
+	root := filepath.Clean(m.Root)
+	names := make([]string, 0)
+	err := filepath.Walk(filepath.Join(root, filepath.FromSlash(path)), func(fp string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		name, err := filepath.Rel(root, fp)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(name))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// FileInfosFromLister lists the files under path and creates a File for
+// each one we can interpret a table name from, files that are not part
+// of a table or are excluded by IncludeFile are skipped.
+func FileInfosFromLister(lister FileLister, path, pattern string) ([]*FileInfo, error) {
+	names, err := lister.List(path)
+	if err != nil {
+		return nil, err
+	}
+	fis := make([]*FileInfo, 0, len(names))
+	for _, name := range names {
+		if !IncludeFile(pattern, path, name) {
+			continue
+		}
+		fi := FileInfoFromName(path, name)
+		if fi.Table == "" {
+			continue
+		}
+		fis = append(fis, fi)
+	}
+	return fis, nil
+}
+
+// IncludeFile is true if the named file should be read into a table.
+// Files or folders below path whose name starts with _ or . such as
+// _SUCCESS markers, _temporary folders and .tmp files are skipped, as
+// are folder placeholder keys.  If a glob pattern is given the name
+// relative to path must also match it.
+//
+//    IncludeFile("tables/*/*.csv", "baseball/", "baseball/tables/players/2017.csv") -> true
+//    IncludeFile("", "baseball/", "baseball/tables/players/_SUCCESS")              -> false
+func IncludeFile(pattern, filePath, name string) bool {
+	rel := name
+	if filePath != "" {
+		rel = strings.TrimPrefix(rel, filePath)
+	}
+	rel = strings.TrimPrefix(rel, "/")
+	if rel == "" || strings.HasSuffix(rel, "/") {
+		return false
+	}
+	for _, part := range strings.Split(rel, "/") {
+		if strings.HasPrefix(part, "_") || strings.HasPrefix(part, ".") {
+			return false
+		}
+	}
+	if pattern == "" {
+		return true
+	}
+	match, err := path.Match(pattern, rel)
+	return err == nil && match
+}
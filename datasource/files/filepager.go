@@ -134,14 +134,22 @@ func (m *FilePager) RunFetcher() {
 func (m *FilePager) fetcher() {
 
 	path := m.fs.path
-	if ft, exists := m.fs.tables[m.table]; exists {
+	ft, exists := m.fs.tables[m.table]
+	if exists {
 		path = filepath.Join(path, ft.PartialPath)
 	}
 
 	q := cloudstorage.Query{Delimiter: "", Prefix: path}
 	q.Sorted()
 	ctx, ctxCancel := context.WithCancel(context.Background())
-	iter := m.fs.store.Objects(ctx, q)
+	var iter cloudstorage.ObjectIterator
+	var listed []*FileInfo
+	if exists && m.fs.Lister != nil {
+		// the Lister has already listed the files of this table
+		listed = ft.Files
+	} else {
+		iter = m.fs.store.Objects(ctx, q)
+	}
 	errCt := 0
 	fetchCt := 0
 	if m.partid >= 0 {
@@ -159,17 +167,26 @@ func (m *FilePager) fetcher() {
 			// If has been closed
 			return
 		default:
-			o, err := iter.Next()
-			if err == iterator.Done {
-				m.readers <- nil
-				return
-			} else if err == context.Canceled || err == context.DeadlineExceeded {
-				// Return to user
-				return
+			var fi *FileInfo
+			if iter == nil {
+				if len(listed) == 0 {
+					m.readers <- nil
+					return
+				}
+				fi, listed = listed[0], listed[1:]
+			} else {
+				o, err := iter.Next()
+				if err == iterator.Done {
+					m.readers <- nil
+					return
+				} else if err == context.Canceled || err == context.DeadlineExceeded {
+					// Return to user
+					return
+				}
+				fi = m.fs.File(o)
 			}
 			m.rowct++
 
-			fi := m.fs.File(o)
 			if fi == nil || fi.Name == "" {
 				// this is expected, not all files are of file type
 				// we are looking for
@@ -221,6 +238,7 @@ func (m *FilePager) fetcher() {
 				F:        f,
 				Exit:     make(chan bool),
 				FileInfo: fi,
+				NoHeader: m.fs.noHeader,
 			}
 
 			// This will back-pressure after we reach our queue size
@@ -0,0 +1,136 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	u "github.com/araddon/gou"
+
+	"github.com/araddon/qlbridge/datasource"
+	"github.com/araddon/qlbridge/value"
+)
+
+// Format is the file format of a source's files, the name of its
+// registered FileHandler
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// FileColumn is a column of a file, its name and the type inferred
+// from a sample of its values
+type FileColumn struct {
+	Name string
+	Type value.ValueType
+}
+
+// InferSchema reads the header and a sample of the first
+// datasource.IntrospectCount rows of a file to find its column names
+// and guess their types as one of int, number, bool, time, string.
+// Empty values don't count, a column whose values are of mixed types
+// is a string, except for ints and numbers which are a number.
+//
+// If hasHeader is false the first row is sampled as data, and the columns
+// are named col0, col1, ... as by datasource.CsvColumnNames.
+//
+// Only the csv format is supported.
+func InferSchema(r io.Reader, format Format, hasHeader bool) ([]FileColumn, error) {
+
+	if Format(strings.ToLower(string(format))) != FormatCSV {
+		return nil, fmt.Errorf("cannot infer schema for format %q, only csv", format)
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1 // allow rows with missing trailing fields
+
+	headers, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	var first []string
+	if !hasHeader {
+		first = headers
+		headers = datasource.CsvColumnNames(len(first))
+	}
+	cols := make([]FileColumn, len(headers))
+	for i, name := range headers {
+		cols[i] = FileColumn{Name: strings.TrimSpace(name), Type: value.UnknownType}
+	}
+
+	for ct := 0; ct < datasource.IntrospectCount; ct++ {
+		row := first
+		first = nil
+		if row == nil {
+			row, err = cr.Read()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		for i, val := range row {
+			if i >= len(cols) || val == "" {
+				continue
+			}
+			cols[i].Type = mergeValueType(cols[i].Type, value.ValueTypeFromString(val))
+		}
+	}
+
+	for i := range cols {
+		if cols[i].Type == value.UnknownType {
+			// no values in sample
+			cols[i].Type = value.StringType
+		}
+	}
+	return cols, nil
+}
+
+// sampleColumns sets the Columns of a csv file inferred from the rows at
+// the start of f, the returned reader reads those rows again then the rest
+// of f.  Gzipped files are sampled uncompressed but returned as is.
+func sampleColumns(fi *FileInfo, f io.ReadCloser, hasHeader bool) io.ReadCloser {
+
+	var sample bytes.Buffer
+	rc := struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(&sample, f), f}
+
+	br := bufio.NewReader(io.TeeReader(f, &sample))
+	var r io.Reader = br
+	first2, err := br.Peek(2)
+	if err == nil && bytes.Equal(first2, []byte{'\x1F', '\x8B'}) {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			u.Warnf("could not open gzip file %q for schema %v", fi.Name, err)
+			return rc
+		}
+		r = gr
+	}
+	cols, err := InferSchema(r, FormatCSV, hasHeader)
+	if err != nil {
+		u.Warnf("could not infer schema of %q %v", fi.Name, err)
+		return rc
+	}
+	fi.Columns = cols
+	return rc
+}
+
+// mergeValueType of a column, from the type so far and of its next value
+func mergeValueType(cur, next value.ValueType) value.ValueType {
+	switch {
+	case cur == value.UnknownType, cur == next:
+		return next
+	case cur == value.IntType && next == value.NumberType,
+		cur == value.NumberType && next == value.IntType:
+		return value.NumberType
+	}
+	return value.StringType
+}
@@ -72,11 +72,17 @@ type FileSource struct {
 	tableSchemas   map[string]*schema.Table
 	tables         map[string]*FileTable
 	path           string
+	pattern        string // optional glob files must match to be part of a table
 	tablePerFolder bool
-	fileType       string // csv, json, proto, customname
+	fileType       Format // csv, json, proto, customname
+	noHeader       bool   // first row of csv files is data, not column names
 	Partitioner    string // random, ??  (date, keyed?)
 	partitionFunc  Partitioner
 	partitionCt    uint64
+	// Lister optionally lists the files tables are found from, such as
+	// the keys of an object store, instead of walking the store.  The
+	// listed files are still read from the store by name.
+	Lister FileLister
 }
 
 // NewFileSource provides a singleton manager for a particular
@@ -137,10 +143,17 @@ func (m *FileSource) init() error {
 		if tablePath := conf.String("path"); tablePath != "" {
 			m.path = tablePath
 		}
+		if pattern := conf.String("pattern"); pattern != "" {
+			m.pattern = pattern
+		}
 		if fileType := conf.String("format"); fileType != "" {
-			m.fileType = fileType
+			m.fileType = Format(fileType)
 		} else {
-			m.fileType = "csv"
+			m.fileType = FormatCSV
+		}
+		if hasHeader, ok := conf.BoolSafe("hasheader"); ok {
+			// csv files are assumed to have a header row unless it is false
+			m.noHeader = !hasHeader
 		}
 		if partitioner := conf.String("partitioner"); partitioner != "" {
 			m.Partitioner = partitioner
@@ -153,7 +166,7 @@ func (m *FileSource) init() error {
 		}
 		m.store = store
 
-		fileHandler, exists := scannerGet(m.fileType)
+		fileHandler, exists := scannerGet(string(m.fileType))
 		if !exists || fileHandler == nil {
 			return fmt.Errorf("Could not find scanner for filetype %q", m.fileType)
 		}
@@ -190,6 +203,9 @@ func (m *FileSource) init() error {
 }
 
 func (m *FileSource) File(o cloudstorage.Object) *FileInfo {
+	if !IncludeFile(m.pattern, m.path, o.Name()) {
+		return nil
+	}
 	fi := m.fh.File(m.path, o)
 	if fi == nil {
 		// u.Debugf("ignoring file, path:%v  %q  is nil", m.path, o.Name())
@@ -216,6 +232,10 @@ func (m *FileSource) findTables() error {
 		return nil
 	}
 
+	if m.Lister != nil {
+		return m.findTablesFromLister()
+	}
+
 	q := cloudstorage.Query{Delimiter: "/", Prefix: m.path}
 	q.Sorted()
 	folders, err := m.store.Folders(context.Background(), q)
@@ -229,6 +249,9 @@ func (m *FileSource) findTables() error {
 
 	// u.Debugf("from path=%q  folders: %v  err=%v", m.path, folders, err)
 	for _, table := range folders {
+		if strings.HasPrefix(table, "_") || strings.HasPrefix(table, ".") {
+			continue
+		}
 		table = strings.ToLower(table)
 		m.tables[table] = &FileTable{Table: table, PartialPath: table}
 		m.tablenames = append(m.tablenames, table)
@@ -261,6 +284,10 @@ func (m *FileSource) findTablesFromFileNames() error {
 				return err
 			}
 
+			if !IncludeFile(m.pattern, m.path, o.Name()) {
+				continue
+			}
+
 			fi := m.fh.File(m.path, o)
 			if fi == nil || fi.Name == "" {
 				u.Warnf("no file?? %#v", o)
@@ -280,6 +307,32 @@ func (m *FileSource) findTablesFromFileNames() error {
 	}
 }
 
+// findTablesFromLister finds the tables from the names of the files
+// the Lister lists below our path, and keeps the files of each table
+// for the FilePager to read them from the store.
+func (m *FileSource) findTablesFromLister() error {
+
+	fis, err := FileInfosFromLister(m.Lister, m.path, m.pattern)
+	if err != nil {
+		u.Errorf("could not list files %v", err)
+		return err
+	}
+	for _, fi := range fis {
+		ft, exists := m.tables[fi.Table]
+		if !exists {
+			ft = &FileTable{Table: fi.Table, PartialPath: fi.PartialPath}
+			m.tables[fi.Table] = ft
+			m.tablenames = append(m.tablenames, fi.Table)
+		}
+		if m.partitionCt > 0 {
+			fi.Partition = m.partitionFunc(m.partitionCt, fi)
+		}
+		ft.Files = append(ft.Files, fi)
+		ft.FileCount++
+	}
+	return nil
+}
+
 // Table satisfys SourceSchema interface to get table schema for given table
 func (m *FileSource) Table(tableName string) (*schema.Table, error) {
 
@@ -28,7 +28,8 @@ func (m *csvFiles) File(path string, obj cloudstorage.Object) *FileInfo {
 	return FileInfoFromCloudObject(path, obj)
 }
 func (m *csvFiles) Scanner(store cloudstorage.StoreReader, fr *FileReader) (schema.ConnScanner, error) {
-	csv, err := datasource.NewCsvSource(fr.Table, 0, fr.F, fr.Exit)
+	fr.F = sampleColumns(fr.FileInfo, fr.F, !fr.NoHeader)
+	csv, err := datasource.NewCsvSourceHeader(fr.Table, 0, fr.F, fr.Exit, !fr.NoHeader)
 	if err != nil {
 		u.Errorf("Could not open file for csv reading %v", err)
 		return nil, err
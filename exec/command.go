@@ -56,7 +56,7 @@ func (m *Command) Run() error {
 	switch kw := m.p.Stmt.Keyword(); kw {
 	case lex.TokenSet:
 		return m.runSet()
-	case lex.TokenRollback, lex.TokenCommit:
+	case lex.TokenRollback, lex.TokenCommit, lex.TokenBegin, lex.TokenStart:
 		u.Debugf("ignorning transaction, not implemented.  %v", kw.String())
 		return nil
 	default:
@@ -55,11 +55,16 @@ func castEvalNoAs(ctx expr.EvalContext, vals []value.Value) (value.Value, bool)
 	if vals[0] == nil || vals[0].Nil() || vals[0].Err() {
 		return nil, false
 	}
-	vt := value.ValueFromString(vals[1].ToString())
+	typ := vals[1].ToString()
+	if i := strings.IndexByte(typ, '('); i > 0 {
+		// precision is not kept, ie CAST(x AS CHAR(10))
+		typ = typ[:i]
+	}
+	vt := value.ValueFromString(typ)
 
 	// http://www.cheatography.com/davechild/cheat-sheets/mysql/
 	if vt == value.UnknownType {
-		switch strings.ToLower(vals[1].ToString()) {
+		switch strings.ToLower(typ) {
 		case "char":
 			vt = value.ByteSliceType
 		default:
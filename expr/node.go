@@ -555,7 +555,12 @@ func (m *FuncNode) WriteDialect(w DialectWriter) {
 	io.WriteString(w, "(")
 	for i, arg := range m.Args {
 		if i > 0 {
-			io.WriteString(w, ", ")
+			if sn, ok := arg.(*StringNode); ok && sn.noQuote && strings.ToLower(m.Name) == "cast" {
+				//  CAST(<expression> AS <type>)
+				io.WriteString(w, " AS ")
+			} else {
+				io.WriteString(w, ", ")
+			}
 		}
 		arg.WriteDialect(w)
 	}
@@ -796,8 +801,8 @@ func NewStringNoQuoteNode(text string) *StringNode {
 	return &StringNode{Text: text, noQuote: true}
 }
 func NewStringNeedsEscape(t lex.Token) *StringNode {
-	newVal, needsEscape := StringUnEscape('"', t.V)
-	return &StringNode{Text: newVal, Quote: t.Quote, needsEscape: needsEscape}
+	newVal := t.UnEscapedValue()
+	return &StringNode{Text: newVal, Quote: t.Quote, needsEscape: newVal != t.V}
 }
 func (m *StringNode) NodeType() string { return "String" }
 func (m *StringNode) String() string {
@@ -810,6 +815,11 @@ func (m *StringNode) String() string {
 	return fmt.Sprintf("%q", m.Text)
 }
 func (m *StringNode) WriteDialect(w DialectWriter) {
+	if m.noQuote && m.Text != "*" {
+		// the data type of a cast
+		io.WriteString(w, m.Text)
+		return
+	}
 	w.WriteLiteral(m.Text)
 }
 func (m *StringNode) Validate() error { return nil }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/araddon/qlbridge/expr"
+	"github.com/araddon/qlbridge/lex"
 )
 
 var pbTests = []string{
@@ -95,3 +96,52 @@ func TestIdentityNames(t *testing.T) {
 		assert.Equal(t, expected, expr.FindIdentityName(0, ex, ""))
 	}
 }
+
+func TestWhereExprTree(t *testing.T) {
+	t.Parallel()
+	ex, err := expr.ParseExpression(`a = 1 AND (b > 2 OR c LIKE 'x%')`)
+	assert.Equal(t, nil, err)
+
+	and, ok := ex.(*expr.BinaryNode)
+	assert.True(t, ok, "expected binary AND but got %T", ex)
+	assert.Equal(t, lex.TokenLogicAnd, and.Operator.T)
+	assert.Equal(t, 2, len(and.Args))
+
+	eq, ok := and.Args[0].(*expr.BinaryNode)
+	assert.True(t, ok, "expected binary = but got %T", and.Args[0])
+	assert.Equal(t, lex.TokenEqual, eq.Operator.T)
+	_, ok = eq.Args[0].(*expr.IdentityNode)
+	assert.True(t, ok, "expected identity but got %T", eq.Args[0])
+	_, ok = eq.Args[1].(*expr.NumberNode)
+	assert.True(t, ok, "expected number but got %T", eq.Args[1])
+
+	// the parenthesized group is its own OR node
+	or, ok := and.Args[1].(*expr.BinaryNode)
+	assert.True(t, ok, "expected binary OR but got %T", and.Args[1])
+	assert.Equal(t, lex.TokenLogicOr, or.Operator.T)
+	assert.True(t, or.Paren)
+
+	gt, ok := or.Args[0].(*expr.BinaryNode)
+	assert.True(t, ok, "expected binary > but got %T", or.Args[0])
+	assert.Equal(t, lex.TokenGT, gt.Operator.T)
+
+	like, ok := or.Args[1].(*expr.BinaryNode)
+	assert.True(t, ok, "expected binary LIKE but got %T", or.Args[1])
+	assert.Equal(t, lex.TokenLike, like.Operator.T)
+	str, ok := like.Args[1].(*expr.StringNode)
+	assert.True(t, ok, "expected string but got %T", like.Args[1])
+	assert.Equal(t, "x%", str.Text)
+
+	ex, err = expr.ParseExpression(`a != 1 AND b IN ("x", "y")`)
+	assert.Equal(t, nil, err)
+	and, ok = ex.(*expr.BinaryNode)
+	assert.True(t, ok, "expected binary AND but got %T", ex)
+	ne, ok := and.Args[0].(*expr.BinaryNode)
+	assert.True(t, ok, "expected binary != but got %T", and.Args[0])
+	assert.Equal(t, lex.TokenNE, ne.Operator.T)
+	in, ok := and.Args[1].(*expr.BinaryNode)
+	assert.True(t, ok, "expected binary IN but got %T", and.Args[1])
+	assert.Equal(t, lex.TokenIN, in.Operator.T)
+	_, ok = in.Args[1].(*expr.ArrayNode)
+	assert.True(t, ok, "expected array but got %T", in.Args[1])
+}
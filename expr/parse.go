@@ -84,7 +84,9 @@ func (m *LexTokenPager) ErrMsg(msg string) error {
 func (m *LexTokenPager) lexNext() {
 	if !m.done {
 		tok := m.lex.NextToken()
-		if tok.T == lex.TokenEOF {
+		switch tok.T {
+		case lex.TokenEOF, lex.TokenEOS:
+			// don't lex into the next statement, callers use Remainder()
 			m.done = true
 		}
 		m.tokens = append(m.tokens, tok)
@@ -324,14 +326,12 @@ func (t *tree) O(depth int) Node {
 	n := t.A(depth)
 	debugf(depth, "O post: n:%v cur:%v ", n, t.Cur())
 	for {
+		t.discardComments()
 		tok := t.Cur()
 		switch tok.T {
 		case lex.TokenLogicOr, lex.TokenOr:
 			t.Next()
 			n = NewBinaryNode(tok, n, t.A(depth+1))
-		case lex.TokenCommentSingleLine:
-			t.Next() // consume --
-			t.Next() // consume comment after --
 		case lex.TokenEOF, lex.TokenEOS, lex.TokenFrom, lex.TokenComma, lex.TokenIf,
 			lex.TokenAs, lex.TokenSelect, lex.TokenLimit:
 			// these are indicators of End of Current Clause, so we can return
@@ -346,6 +346,7 @@ func (t *tree) A(depth int) Node {
 	debugf(depth, "A  pre: %v", t.Cur())
 	n := t.C(depth)
 	for {
+		t.discardComments()
 		debugf(depth, "A post:  cur=%v peek=%v", t.Cur(), t.Peek())
 		switch tok := t.Cur(); tok.T {
 		case lex.TokenLogicAnd, lex.TokenAnd:
@@ -391,6 +392,7 @@ func (t *tree) C(depth int) Node {
 
 func (t *tree) cInner(n Node, depth int) Node {
 	for {
+		t.discardComments()
 		debugf(depth, "cInner:  tok:  cur=%v peek=%v n=%v", t.Cur(), t.Peek(), n)
 		switch cur := t.Cur(); cur.T {
 		case lex.TokenEqual, lex.TokenEqualEqual, lex.TokenNE, lex.TokenGT, lex.TokenGE,
@@ -459,6 +461,7 @@ func (t *tree) P(depth int) Node {
 	n := t.M(depth)
 	debugf(depth, "P post: %v", t.Cur())
 	for {
+		t.discardComments()
 		switch cur := t.Cur(); cur.T {
 		case lex.TokenPlus, lex.TokenMinus:
 			t.Next()
@@ -474,6 +477,7 @@ func (t *tree) M(depth int) Node {
 	n := t.F(depth)
 	debugf(depth, "M post: %v  %v", t.Cur(), n)
 	for {
+		t.discardComments()
 		switch cur := t.Cur(); cur.T {
 		case lex.TokenStar, lex.TokenMultiply, lex.TokenDivide, lex.TokenModulus:
 			t.Next()
@@ -487,6 +491,7 @@ func (t *tree) M(depth int) Node {
 // F -> v | "(" O ")" | "!" O | "-" O | "NOT" C | "EXISTS" v | "IS" O | "AND (" O ")" | "OR (" O ")"
 func (t *tree) F(depth int) Node {
 	debugf(depth, "F: %v", t.Cur())
+	t.discardComments()
 
 	// Urnary operations
 	switch cur := t.Cur(); cur.T {
@@ -600,7 +605,7 @@ func (t *tree) v(depth int) Node {
 		n := NewStringNeedsEscape(cur)
 		t.Next()
 		return n
-	case lex.TokenIdentity:
+	case lex.TokenIdentity, lex.TokenVariable:
 		n := NewIdentityNode(&cur)
 		t.Next() // Consume identity
 
@@ -608,6 +613,9 @@ func (t *tree) v(depth int) Node {
 	case lex.TokenNull:
 		t.Next()
 		return NewNull(cur)
+	case lex.TokenTypeDef:
+		// CONVERT(field, DECIMAL(10,2))
+		return t.typeDef()
 	case lex.TokenStar:
 		n := NewStringNoQuoteNode(cur.V)
 		t.Next()
@@ -691,14 +699,27 @@ func (t *tree) Func(depth int, funcTok lex.Token) (fn *FuncNode) {
 		if t.Cur().T != lex.TokenAs {
 			t.unexpected(t.Cur(), "func AS")
 		}
-		// This really isn't correct, we probably need an OperatorNode?
-		fn.append(NewStringNodeToken(t.Next()))
-		if t.Cur().T != lex.TokenIdentity {
+		t.Next() // Consume AS, the type is the 2nd arg
+		if t.Cur().T != lex.TokenIdentity && t.Cur().T != lex.TokenTypeDef {
 			t.unexpected(t.Cur(), "func AS exected Identity")
 		}
-		fn.append(NewStringNodeToken(t.Next()))
+		fn.append(t.typeDef())
+		t.expect(lex.TokenRightParenthesis, "func")
+		t.Next()
 		return fn
 	default:
+		// the aggregate quantifier:   COUNT(DISTINCT a)  is COUNT(DISTINCT(a))
+		// ALL is what an aggregate does anyway, so is dropped
+		args := fn
+		switch t.Cur().T {
+		case lex.TokenDistinct:
+			args = NewFuncNode(t.Cur().V, Func{Name: t.Cur().V, Eval: EmptyEvalFunc})
+			args.Missing = true
+			fn.append(args)
+			t.Next()
+		case lex.TokenAll:
+			t.Next()
+		}
 		lastComma := false
 		for {
 			node = nil
@@ -707,16 +728,16 @@ func (t *tree) Func(depth int, funcTok lex.Token) (fn *FuncNode) {
 			case lex.TokenRightParenthesis:
 				t.Next()
 				if node != nil {
-					fn.append(node)
+					args.append(node)
 				}
 				return
 			case lex.TokenEOF, lex.TokenEOS, lex.TokenFrom:
 				if node != nil {
-					fn.append(node)
+					args.append(node)
 				}
 				return
 			case lex.TokenComma:
-				if len(fn.Args) == 0 || t.Peek().T == lex.TokenComma || lastComma {
+				if len(args.Args) == 0 || t.Peek().T == lex.TokenComma || lastComma {
 					t.unexpected(tok, "Wanted argument but got comma")
 				}
 				lastComma = true
@@ -731,19 +752,19 @@ func (t *tree) Func(depth int, funcTok lex.Token) (fn *FuncNode) {
 			switch tok.T {
 			case lex.TokenComma:
 				if node != nil {
-					fn.append(node)
+					args.append(node)
 				}
 				lastComma = true
 				// continue
 			case lex.TokenRightParenthesis:
 				if node != nil {
-					fn.append(node)
+					args.append(node)
 				}
 				t.Next()
 				return
 			case lex.TokenEOF, lex.TokenEOS, lex.TokenFrom, lex.TokenAs:
 				if node != nil {
-					fn.append(node)
+					args.append(node)
 				}
 				t.Next()
 				return
@@ -753,7 +774,7 @@ func (t *tree) Func(depth int, funcTok lex.Token) (fn *FuncNode) {
 				//     toint(str_item * 5)
 				node = t.O(depth + 1)
 				if node != nil {
-					fn.append(node)
+					args.append(node)
 				}
 			default:
 				t.unexpected(tok, "func")
@@ -764,6 +785,27 @@ func (t *tree) Func(depth int, funcTok lex.Token) (fn *FuncNode) {
 	}
 }
 
+// typeDef is the data type of a CAST or CONVERT as an unquoted string
+// node, including its precision if any:   DECIMAL(10,2)
+func (t *tree) typeDef() Node {
+	typ := t.Next().V
+	if t.Cur().T != lex.TokenLeftParenthesis {
+		return NewStringNoQuoteNode(typ)
+	}
+	typ += t.Next().V
+	for {
+		tok := t.Next()
+		switch tok.T {
+		case lex.TokenInteger, lex.TokenComma:
+			typ += tok.V
+		case lex.TokenRightParenthesis:
+			return NewStringNoQuoteNode(typ + tok.V)
+		default:
+			t.unexpected(tok, "type precision")
+		}
+	}
+}
+
 // get Function from Global
 func (t *tree) getFunction(name string) (v Func, ok bool) {
 	if t.fr != nil {
@@ -825,8 +867,7 @@ arrayLoop:
 		case lex.TokenValue:
 			vals = append(vals, value.NewStringValue(tok.V))
 		case lex.TokenValueEscaped:
-			newVal, _ := StringUnEscape('"', tok.V)
-			vals = append(vals, value.NewStringValue(newVal))
+			vals = append(vals, value.NewStringValue(tok.UnEscapedValue()))
 		case lex.TokenInteger:
 			fv, err := strconv.ParseFloat(tok.V, 64)
 			if err == nil {
@@ -895,7 +936,8 @@ func nodeArray(t *tree, depth int) ([]Node, error, bool) {
 				break nextNodeLoop
 			case lex.TokenComment, lex.TokenCommentML,
 				lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd,
-				lex.TokenCommentSingleLine, lex.TokenCommentSlashes:
+				lex.TokenCommentSingleLine, lex.TokenCommentSlashes,
+				lex.TokenHint, lex.TokenMysqlVersionComment:
 				// skip, currently ignore these
 				t.Next()
 			case lex.TokenRightParenthesis:
@@ -908,6 +950,23 @@ func nodeArray(t *tree, depth int) ([]Node, error, bool) {
 	}
 }
 
+// discardComments between the tokens of an expression
+//
+//    a = /* one */ 1
+func (t *tree) discardComments() {
+	for {
+		switch t.Cur().T {
+		case lex.TokenComment, lex.TokenCommentML,
+			lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd,
+			lex.TokenCommentSingleLine, lex.TokenCommentSlashes,
+			lex.TokenHint, lex.TokenMysqlVersionComment:
+			t.Next()
+		default:
+			return
+		}
+	}
+}
+
 func (t *tree) discardNewLinesAndComments() {
 	for {
 		// We are going to loop until we find the first Non-Comment Token
@@ -916,7 +975,8 @@ func (t *tree) discardNewLinesAndComments() {
 			t.Next() // Consume new line
 		case lex.TokenComment, lex.TokenCommentML,
 			lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd,
-			lex.TokenCommentSingleLine, lex.TokenCommentSlashes:
+			lex.TokenCommentSingleLine, lex.TokenCommentSlashes,
+			lex.TokenHint, lex.TokenMysqlVersionComment:
 			// skip, currently ignore these
 			t.Next()
 		default:
@@ -325,6 +325,26 @@ var exprTests = []exprTest{
 		`AND ( x == "y", stuff == x )`,
 		true,
 	},
+	{
+		`CAST(price AS DECIMAL(10, 2)) > 1`,
+		`CAST(price AS DECIMAL(10,2)) > 1`,
+		true,
+	},
+	{
+		`count(DISTINCT user_id)`,
+		`count(DISTINCT(user_id))`,
+		true,
+	},
+	{
+		`sum(ALL x) > 1`,
+		`sum(x) > 1`,
+		true,
+	},
+	{
+		`a = /* one */ 1 /* two */ AND b > 2`,
+		`a = 1 AND b > 2`,
+		true,
+	},
 }
 
 func TestParseExpressions(t *testing.T) {
@@ -22,8 +22,70 @@ type Dialect struct {
 	Statements      []*Clause
 	IdentityQuoting []byte
 	inited          bool
+	// BackslashEscapes decodes backslash escape sequences (\n, \t, \\, \', \")
+	// in quoted values as MySQL does, otherwise a backslash is literal
+	BackslashEscapes bool
+	// SetOperators are the keywords that combine the results of two select
+	// statements (UNION, INTERSECT, EXCEPT) and the token each one emits
+	SetOperators map[string]TokenType
+	// HashComments allows # to start a single line comment as MySQL does,
+	// in ansi sql # is not a comment
+	HashComments bool
+	// RegexpOperators are the enabled spellings of the regular expression
+	// match operators (REGEXP, RLIKE, ~, !~) and the token each one emits
+	RegexpOperators map[string]TokenType
+	// ILike enables the Postgres case insensitive ILIKE, otherwise ilike
+	// is not a keyword
+	ILike bool
+	// Returning allows a trailing RETURNING column list on DELETE and UPDATE
+	// as Postgres does
+	Returning bool
+	// TruncateWithoutTable allows TRUNCATE <table> without the TABLE
+	// keyword, otherwise it must be TRUNCATE TABLE <table>
+	TruncateWithoutTable bool
+	// Placeholders are the styles of bind parameter allowed in value
+	// positions, which are lexed as TokenPlaceholder
+	Placeholders PlaceholderStyle
+	// StrictEquality only allows = for equality, the == operator some
+	// expression languages use is an error.  Otherwise == is lexed as a
+	// TokenEqualEqual, which the vm, rel and generators treat the same as
+	// TokenEqual while still writing it back out as ==
+	StrictEquality bool
+	// CastOperator enables the Postgres value::type cast shorthand
+	CastOperator bool
+	// DisableBitwise turns off the & | ^ << >> bitwise operators for
+	// engines that do not support them
+	DisableBitwise bool
+	// RejectEmptyStatements makes an empty statement, such as the ;;
+	// of "SELECT a FROM t;; SELECT b FROM t", an error instead of skipped
+	RejectEmptyStatements bool
+	// MysqlVersionComments lexes the sql inside MySQL version gated
+	// comments /*!40101 SET NAMES utf8 */ as sql, between a
+	// TokenMysqlVersionComment of the version and a TokenCommentEnd,
+	// instead of as one opaque comment
+	MysqlVersionComments bool
+	// NullsOrdering allows NULLS FIRST or NULLS LAST after each ORDER BY
+	// column as Postgres does, otherwise nulls is an identity
+	NullsOrdering bool
+	// Tags lexes the tags of time-series dialects, the tag(host) of
+	// GROUP BY tag(host) and SHOW TAG KEYS, SHOW TAG VALUES WITH KEY = host
+	// as TokenTag and TokenKey, otherwise tag(host) is a function
+	Tags bool
 }
 
+// PlaceholderStyle is a set of bind parameter styles
+type PlaceholderStyle uint8
+
+const (
+	// PlaceholderQuestion is the positional ? of MySQL and JDBC, each is
+	// numbered in order of appearance in its statement
+	PlaceholderQuestion PlaceholderStyle = 1 << iota
+	// PlaceholderDollar is the numbered $1 of Postgres
+	PlaceholderDollar
+	// PlaceholderNamed is the named :user_id of Oracle and many drivers
+	PlaceholderNamed
+)
+
 func (m *Dialect) Init() {
 	if m.inited {
 		return
@@ -57,8 +119,7 @@ func (c *Clause) MatchesKeyword(peekWord string, l *Lexer) bool {
 	} else if c.keyword == peekWord && !c.multiWord {
 		return true
 	} else if c.multiWord {
-		//u.Infof("multi? %q", l.PeekX(len(c.fullWord)))
-		if strings.ToLower(l.PeekX(len(c.fullWord))) == c.fullWord {
+		if l.peekMultiWord(c.fullWord) {
 			return true
 		}
 	}
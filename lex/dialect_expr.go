@@ -30,3 +30,12 @@ var LogicalExpressionDialect *Dialect = &Dialect{
 		{Token: TokenNil, Clauses: logicalEpressions},
 	},
 }
+
+// creates a new lexer for the input string using LogicalExpressionDialect
+//  for bare boolean expressions without any sql keywords
+//
+//    user.age > 21 AND contains(email, "@corp.com")
+//
+func NewExpressionLexer(input string) *Lexer {
+	return NewLexer(input, LogicalExpressionDialect)
+}
@@ -125,3 +125,45 @@ func TestLexLogicalDialect(t *testing.T) {
 			tv(TokenInteger, "2"),
 		})
 }
+
+func TestLexExpressionLexer(t *testing.T) {
+	verifyLexerTokens(t, NewExpressionLexer(`user.age > 21 AND contains(email, "@corp.com")`),
+		[]Token{
+			tv(TokenIdentity, "user.age"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "21"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenUdfExpr, "contains"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "email"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "@corp.com"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+
+	verifyLexerTokens(t, NewExpressionLexer(`NOT (a == 1 OR eq(lower(b), "x")) AND c != 2.5`),
+		[]Token{
+			tv(TokenNegate, "NOT"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "a"),
+			tv(TokenEqualEqual, "=="),
+			tv(TokenInteger, "1"),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenUdfExpr, "eq"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenUdfExpr, "lower"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "b"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "x"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "c"),
+			tv(TokenNE, "!="),
+			tv(TokenFloat, "2.5"),
+			tv(TokenEOF, ""),
+		})
+}
@@ -120,6 +120,13 @@ var FilterSelectStatement = []*Clause{
 	{Token: TokenEOF, Lexer: LexEndOfStatement, Optional: false},
 }
 
+// a standalone filter expression without the FILTER keyword
+//
+//    AND ( x == "y", score > 0.5, domain IN ("a","b") )
+var filterExpressions = []*Clause{
+	{Token: TokenNil, Lexer: LexFilterClause},
+}
+
 // FilterQL is a Where Clause filtering language slightly
 //   more DSL'ish than SQL Where Clause
 //
@@ -127,6 +134,8 @@ var FilterQLDialect *Dialect = &Dialect{
 	Statements: []*Clause{
 		{Token: TokenFilter, Clauses: FilterStatement},
 		{Token: TokenSelect, Clauses: FilterSelectStatement},
+		{Token: TokenNil, Clauses: filterExpressions},
 	},
 	IdentityQuoting: IdentityQuotingWSingleQuote,
+	HashComments:    true,
 }
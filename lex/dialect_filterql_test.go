@@ -214,6 +214,91 @@ func TestFilterQLBasic(t *testing.T) {
 			tv(TokenAlias, "ALIAS"),
 			tv(TokenIdentity, "withstuff"),
 		})
+
+	// single quoted identities escape a quote by doubling it
+	verifyFilterQLTokens(t, `FILTER 'o''neil' == 5`,
+		[]Token{
+			tv(TokenFilter, "FILTER"),
+			tv(TokenIdentity, "o'neil"),
+			tv(TokenEqualEqual, "=="),
+			tv(TokenInteger, "5"),
+		})
+}
+
+func TestFilterQLNested(t *testing.T) {
+	verifyFilterQLTokens(t, `FILTER AND ( x > 5, y == "a", NOT INCLUDE other ) FROM users`,
+		[]Token{
+			tv(TokenFilter, "FILTER"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "x"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "5"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "y"),
+			tv(TokenEqualEqual, "=="),
+			tv(TokenValue, "a"),
+			tv(TokenComma, ","),
+			tv(TokenNegate, "NOT"),
+			tv(TokenInclude, "INCLUDE"),
+			tv(TokenIdentity, "other"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "users"),
+			tv(TokenEOF, ""),
+		})
+
+	// AND/OR blocks nested three deep
+	verifyFilterQLTokens(t, `
+    FILTER OR (
+        AND (
+            a > 1
+            , OR ( b == "x", NOT INCLUDE c )
+        )
+        , d < 2
+    )
+    FROM users
+    LIMIT 10
+    `,
+		[]Token{
+			tv(TokenFilter, "FILTER"),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenNewLine, ""),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenNewLine, ""),
+			tv(TokenIdentity, "a"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenNewLine, ""),
+			tv(TokenComma, ","),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "b"),
+			tv(TokenEqualEqual, "=="),
+			tv(TokenValue, "x"),
+			tv(TokenComma, ","),
+			tv(TokenNegate, "NOT"),
+			tv(TokenInclude, "INCLUDE"),
+			tv(TokenIdentity, "c"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenNewLine, ""),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenNewLine, ""),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "d"),
+			tv(TokenLT, "<"),
+			tv(TokenInteger, "2"),
+			tv(TokenNewLine, ""),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenNewLine, ""),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "users"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "10"),
+			tv(TokenEOF, ""),
+		})
 }
 
 func TestFilterQLIntersects(t *testing.T) {
@@ -231,3 +316,69 @@ func TestFilterQLIntersects(t *testing.T) {
 			tv(TokenRightParenthesis, ")"),
 		})
 }
+
+func TestFilterQLExpression(t *testing.T) {
+	// a filter expression on its own, without the FILTER keyword
+	verifyFilterQLTokens(t, `AND ( x == "y", score > 0.5, domain IN ("a","b"), OR ( NOT a, AND (b < 2, NOT c) ) )`,
+		[]Token{
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "x"),
+			tv(TokenEqualEqual, "=="),
+			tv(TokenValue, "y"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "score"),
+			tv(TokenGT, ">"),
+			tv(TokenFloat, "0.5"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "domain"),
+			tv(TokenIN, "IN"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenValue, "a"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "b"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenNegate, "NOT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "b"),
+			tv(TokenLT, "<"),
+			tv(TokenInteger, "2"),
+			tv(TokenComma, ","),
+			tv(TokenNegate, "NOT"),
+			tv(TokenIdentity, "c"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+
+	verifyFilterQLTokens(t, `NOT OR ( x > 5, y == "a" )`,
+		[]Token{
+			tv(TokenNegate, "NOT"),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "x"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "5"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "y"),
+			tv(TokenEqualEqual, "=="),
+			tv(TokenValue, "a"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+
+	verifyFilterQLTokens(t, `x > 5`,
+		[]Token{
+			tv(TokenIdentity, "x"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "5"),
+			tv(TokenEOF, ""),
+		})
+}
@@ -3,21 +3,26 @@ package lex
 import (
 	u "github.com/araddon/gou"
 	"strings"
+	"unicode"
 )
 
 var _ = u.EMPTY
 
 var SqlSelect = []*Clause{
 	{Token: TokenSelect, Lexer: LexSelectClause, Name: "sqlSelect.Select"},
-	{Token: TokenInto, Lexer: LexIdentifierOfType(TokenTable), Optional: true, Name: "sqlSelect.INTO"},
+	{Token: TokenInto, Lexer: LexSelectInto, Optional: true, Name: "sqlSelect.INTO"},
 	{Token: TokenFrom, Lexer: LexTableReferenceFirst, Optional: true, Repeat: false, Clauses: fromSource, Name: "sqlSelect.From"},
 	{KeywordMatcher: sourceMatch, Optional: true, Repeat: true, Clauses: moreSources, Name: "sqlSelect.sources"},
 	{Token: TokenWhere, Lexer: LexConditionalClause, Optional: true, Clauses: whereQuery, Name: "sqlSelect.where"},
-	{Token: TokenGroupBy, Lexer: LexColumns, Optional: true, Name: "sqlSelect.groupby"},
+	{Token: TokenGroupBy, Lexer: LexGroupByColumns, Optional: true, Name: "sqlSelect.groupby"},
 	{Token: TokenHaving, Lexer: LexConditionalClause, Optional: true, Name: "sqlSelect.having"},
+	{KeywordMatcher: setOperatorMatch, Lexer: LexSetOperator, Optional: true, Name: "sqlSelect.setoperator"},
 	{Token: TokenOrderBy, Lexer: LexOrderByColumn, Optional: true, Name: "sqlSelect.orderby"},
 	{Token: TokenLimit, Lexer: LexLimit, Optional: true, Name: "sqlSelect.limit"},
-	{Token: TokenOffset, Lexer: LexNumber, Optional: true, Name: "sqlSelect.offset"},
+	{Token: TokenOffset, Lexer: LexOffset, Optional: true, Name: "sqlSelect.offset"},
+	{Token: TokenSkip, KeywordMatcher: skipMatch, Lexer: LexInteger, Optional: true, Name: "sqlSelect.skip"},
+	{Token: TokenFetch, KeywordMatcher: fetchMatch, Lexer: LexFetch, Optional: true, Name: "sqlSelect.fetch"},
+	{Token: TokenInto, Lexer: LexSelectInto, Optional: true, Name: "sqlSelect.INTO.end"},
 	{Token: TokenWith, Lexer: LexJsonOrKeyValue, Optional: true, Name: "sqlSelect.with"},
 	{Token: TokenAlias, Lexer: LexIdentifier, Optional: true, Name: "sqlSelect.alias"},
 	{Token: TokenEOF, Lexer: LexEndOfStatement, Optional: false, Name: "sqlSelect.eos"},
@@ -26,7 +31,7 @@ var SqlSelect = []*Clause{
 // find any keyword that starts a source
 //    FROM <name>
 //    FROM (select ...)
-//         [(INNER | LEFT)] JOIN
+//         [(LEFT | RIGHT | FULL)] [(INNER | OUTER | CROSS)] JOIN
 func sourceMatch(c *Clause, peekWord string, l *Lexer) bool {
 	//u.Debugf("%p sourceMatch?   peekWord: %s", c, peekWord)
 	switch peekWord {
@@ -34,12 +39,89 @@ func sourceMatch(c *Clause, peekWord string, l *Lexer) bool {
 		return true
 	case "select":
 		return true
-	case "left", "right", "inner", "outer", "join":
+	case "left", "right", "full", "inner", "outer", "cross", "join":
 		return true
 	}
 	return false
 }
 
+// skip is only the OFFSET synonym SKIP n when followed by the row count,
+// so a column named skip is still a column
+func skipMatch(c *Clause, peekWord string, l *Lexer) bool {
+	if peekWord != "skip" {
+		return false
+	}
+	next := l.wordAfter(peekWord)
+	return len(next) > 0 && isDigit(rune(next[0]))
+}
+
+// fetch is only a keyword in FETCH FIRST or FETCH NEXT
+func fetchMatch(c *Clause, peekWord string, l *Lexer) bool {
+	if peekWord != "fetch" {
+		return false
+	}
+	switch strings.ToLower(l.wordAfter(peekWord)) {
+	case "first", "next":
+		return true
+	}
+	return false
+}
+
+// SqlSetOperators are the ansi sql set operators, a dialect may register
+// more of them, ie "minus" as the Oracle/MySQL synonym for TokenExcept
+var SqlSetOperators = map[string]TokenType{
+	"union":     TokenUnion,
+	"intersect": TokenIntersect,
+	"except":    TokenExcept,
+}
+
+// SqlRegexpOperators are the regular expression match operators of the
+// sql dialect, MySQL's REGEXP and RLIKE and the Postgres ~ and !~, and
+// their case insensitive ~* and !~*.  A NOT REGEXP is a TokenNegate
+// followed by the TokenRegexp.
+var SqlRegexpOperators = map[string]TokenType{
+	"regexp": TokenRegexp,
+	"rlike":  TokenRegexp,
+	"~":      TokenRegexp,
+	"!~":     TokenNotRegexp,
+	"~*":     TokenIRegexp,
+	"!~*":    TokenNotIRegexp,
+}
+
+// find a set operator registered on the dialect
+func setOperatorMatch(c *Clause, peekWord string, l *Lexer) bool {
+	_, ok := l.dialect.SetOperators[peekWord]
+	return ok
+}
+
+// LexSetOperator lexes a set operator and optional ALL, and then the select
+// statement that follows it, any ORDER BY or LIMIT belong to the last select.
+//
+//    <select_stmt> (UNION | INTERSECT | EXCEPT) [ALL] <select_stmt> ...
+//
+func LexSetOperator(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	word := l.PeekWord()
+	op, ok := l.dialect.SetOperators[strings.ToLower(word)]
+	if !ok {
+		return l.errorf("expected set operator but got %q", word)
+	}
+	l.ConsumeWord(word)
+	l.Emit(op)
+	l.SkipWhiteSpaces()
+	if strings.ToLower(l.PeekWord()) == "all" {
+		l.ConsumeWord("all")
+		l.Emit(TokenAll)
+		l.SkipWhiteSpaces()
+	}
+	if strings.ToLower(l.PeekWord()) != "select" {
+		return l.errorf("expected SELECT after %s but got %q", strings.ToUpper(word), l.PeekWord())
+	}
+	// start over as a new statement, nothing from the prior select is pending
+	l.stack = l.stack[:0]
+	return LexDialectForStatement
+}
+
 // Look for end of statement defined by either a semicolon or end of file
 func LexEndOfSubStatement(l *Lexer) StateFn {
 	l.SkipWhiteSpaces()
@@ -56,10 +138,12 @@ var fromSource = []*Clause{
 	{Token: TokenFrom, Lexer: LexTableReferenceFirst, Optional: true, Repeat: true, Name: "fromSource.From"},
 	{Token: TokenWhere, Lexer: LexConditionalClause, Optional: true, Name: "fromSource.Where"},
 	{Token: TokenHaving, Lexer: LexConditionalClause, Optional: true, Name: "fromSource.having"},
-	{Token: TokenGroupBy, Lexer: LexColumns, Optional: true, Name: "fromSource.GroupBy"},
+	{Token: TokenGroupBy, Lexer: LexGroupByColumns, Optional: true, Name: "fromSource.GroupBy"},
 	{Token: TokenOrderBy, Lexer: LexOrderByColumn, Optional: true, Name: "fromSource.OrderBy"},
 	{Token: TokenLimit, Lexer: LexLimit, Optional: true, Name: "fromSource.Limit"},
-	{Token: TokenOffset, Lexer: LexNumber, Optional: true, Name: "fromSource.Offset"},
+	{Token: TokenOffset, Lexer: LexOffset, Optional: true, Name: "fromSource.Offset"},
+	{Token: TokenSkip, KeywordMatcher: skipMatch, Lexer: LexInteger, Optional: true, Name: "fromSource.Skip"},
+	{Token: TokenFetch, KeywordMatcher: fetchMatch, Lexer: LexFetch, Optional: true, Name: "fromSource.Fetch"},
 	{Token: TokenRightParenthesis, Lexer: LexEndOfSubStatement, Optional: true, Name: "fromSource.EndParen"},
 	{Token: TokenAs, Lexer: LexIdentifier, Optional: true, Name: "fromSource.As"},
 	{Token: TokenOn, Lexer: LexConditionalClause, Optional: true, Name: "fromSource.On"},
@@ -71,11 +155,13 @@ var moreSources = []*Clause{
 	{Token: TokenFrom, Lexer: LexTableReferenceFirst, Optional: true, Repeat: true, Name: "moreSources.From"},
 	{Token: TokenWhere, Lexer: LexConditionalClause, Optional: true, Name: "moreSources.Where"},
 	{Token: TokenHaving, Lexer: LexConditionalClause, Optional: true, Name: "moreSources.Having"},
-	{Token: TokenGroupBy, Lexer: LexColumns, Optional: true, Name: "moreSources.GroupBy"},
+	{Token: TokenGroupBy, Lexer: LexGroupByColumns, Optional: true, Name: "moreSources.GroupBy"},
 	{Token: TokenOrderBy, Lexer: LexOrderByColumn, Optional: true, Name: "moreSources.OrderBy"},
 	{Token: TokenLimit, Lexer: LexLimit, Optional: true, Name: "moreSources.Limit"},
-	{Token: TokenOffset, Lexer: LexNumber, Optional: true, Name: "moreSources.Offset"},
-	{Token: TokenRightParenthesis, Lexer: LexEndOfSubStatement, Optional: false, Name: "moreSources.EndParen"},
+	{Token: TokenOffset, Lexer: LexOffset, Optional: true, Name: "moreSources.Offset"},
+	{Token: TokenSkip, KeywordMatcher: skipMatch, Lexer: LexInteger, Optional: true, Name: "moreSources.Skip"},
+	{Token: TokenFetch, KeywordMatcher: fetchMatch, Lexer: LexFetch, Optional: true, Name: "moreSources.Fetch"},
+	{Token: TokenRightParenthesis, Lexer: LexEndOfSubStatement, Optional: true, Name: "moreSources.EndParen"},
 	{Token: TokenAs, Lexer: LexIdentifier, Optional: true, Name: "moreSources.As"},
 	{Token: TokenOn, Lexer: LexConditionalClause, Optional: true, Name: "moreSources.On"},
 }
@@ -85,17 +171,26 @@ var whereQuery = []*Clause{
 	{Token: TokenFrom, Lexer: LexTableReferences, Optional: true, Repeat: true, Name: "whereQuery.From"},
 	{Token: TokenWhere, Lexer: LexConditionalClause, Optional: true, Name: "whereQuery.Where"},
 	{Token: TokenHaving, Lexer: LexConditionalClause, Optional: true, Name: "whereQuery.Having"},
-	{Token: TokenGroupBy, Lexer: LexColumns, Optional: true, Name: "whereQuery.GroupBy"},
+	{Token: TokenGroupBy, Lexer: LexGroupByColumns, Optional: true, Name: "whereQuery.GroupBy"},
 	{Token: TokenOrderBy, Lexer: LexOrderByColumn, Optional: true, Name: "whereQuery.OrderBy"},
-	{Token: TokenLimit, Lexer: LexNumber, Optional: true, Name: "whereQuery.Limit"},
+	{Token: TokenLimit, Lexer: LexInteger, Optional: true, Name: "whereQuery.Limit"},
 	{Token: TokenRightParenthesis, Lexer: LexEndOfSubStatement, Optional: false, Name: "whereQuery.EOS"},
 }
 
+// SqlWith is a select statement preceded by one or more common table expressions
+//
+//    WITH <name> AS ( <select_stmt> ) [, <name> AS ( <select_stmt> )]* <select_stmt>
+//
+var SqlWith = []*Clause{
+	{Token: TokenWith, Lexer: LexCommonTableExpr, Name: "sqlWith.with"},
+}
+
 var SqlUpdate = []*Clause{
 	{Token: TokenUpdate, Lexer: LexIdentifierOfType(TokenTable)},
 	{Token: TokenSet, Lexer: LexColumns},
 	{Token: TokenWhere, Lexer: LexColumns, Optional: true},
-	{Token: TokenLimit, Lexer: LexNumber, Optional: true},
+	{Token: TokenLimit, Lexer: LexInteger, Optional: true},
+	{Token: TokenReturning, Lexer: LexReturning, Optional: true},
 	{Token: TokenWith, Lexer: LexJsonOrKeyValue, Optional: true},
 }
 
@@ -112,17 +207,52 @@ var SqlInsert = []*Clause{
 	{Token: TokenSet, Lexer: LexTableColumns, Optional: true},
 	{Token: TokenSelect, Optional: true, Clauses: insertSubQuery},
 	{Token: TokenValues, Lexer: LexTableColumns, Optional: true},
+	{KeywordMatcher: onDuplicateKeyMatch, Lexer: LexOnDuplicateKeyUpdate, Optional: true, Name: "insert.onduplicate"},
 	{Token: TokenWith, Lexer: LexJsonOrKeyValue, Optional: true},
 }
 
+// the keywords of the MySQL upsert
+var onDuplicateKeyUpdate = [...]TokenType{TokenOn, TokenDuplicate, TokenKey, TokenUpdate}
+
+func onDuplicateKeyMatch(c *Clause, peekWord string, l *Lexer) bool {
+	return peekWord == "on" && l.peekMultiWord("on duplicate key update")
+}
+
+// LexOnDuplicateKeyUpdate lexes the MySQL upsert suffix of an INSERT, whose
+// assignments are lexed as the SET of an UPDATE
+//
+//    INSERT INTO t (a, b) VALUES (1, 2) ON DUPLICATE KEY UPDATE b = VALUES(b)
+//
+func LexOnDuplicateKeyUpdate(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	if !onDuplicateKeyMatch(nil, strings.ToLower(l.PeekWord()), l) {
+		// the clause state while lexing the assignments
+		return LexColumns
+	}
+	for _, tok := range onDuplicateKeyUpdate {
+		l.SkipWhiteSpaces()
+		l.ConsumeWord(tok.String())
+		l.Emit(tok)
+	}
+	return LexColumns
+}
+
+// SqlValues is a standalone VALUES row constructor
+//
+//    VALUES (1, 'a'), (2, 'b')
+//
+var SqlValues = []*Clause{
+	{Token: TokenValues, Lexer: lexValuesStatement},
+}
+
 var insertSubQuery = []*Clause{
 	{Token: TokenSelect, Lexer: LexSelectClause},
 	{Token: TokenFrom, Lexer: LexTableReferences, Optional: true, Repeat: true},
 	{Token: TokenWhere, Lexer: LexConditionalClause, Optional: true},
 	{Token: TokenHaving, Lexer: LexConditionalClause, Optional: true},
-	{Token: TokenGroupBy, Lexer: LexColumns, Optional: true},
+	{Token: TokenGroupBy, Lexer: LexGroupByColumns, Optional: true},
 	{Token: TokenOrderBy, Lexer: LexOrderByColumn, Optional: true},
-	{Token: TokenLimit, Lexer: LexNumber, Optional: true},
+	{Token: TokenLimit, Lexer: LexInteger, Optional: true},
 }
 
 var SqlReplace = []*Clause{
@@ -138,35 +268,54 @@ var SqlDelete = []*Clause{
 	{Token: TokenFrom, Lexer: LexIdentifierOfType(TokenTable)},
 	{Token: TokenSet, Lexer: LexColumns, Optional: true},
 	{Token: TokenWhere, Lexer: LexColumns, Optional: true},
-	{Token: TokenLimit, Lexer: LexNumber, Optional: true},
+	{Token: TokenLimit, Lexer: LexInteger, Optional: true},
+	{Token: TokenReturning, Lexer: LexReturning, Optional: true},
 	{Token: TokenWith, Lexer: LexJsonOrKeyValue, Optional: true},
 }
 
 var SqlAlter = []*Clause{
 	{Token: TokenAlter, Lexer: LexEmpty},
 	{Token: TokenTable, Lexer: LexIdentifier},
-	{Token: TokenChange, Lexer: LexDdlAlterColumn},
+	{KeywordMatcher: alterColumnMatch, Lexer: LexDdlAlterColumn, Name: "sqlAlter.column"},
 	{Token: TokenWith, Lexer: LexJsonOrKeyValue, Optional: true},
 }
 
+// find the keyword that starts an alteration of a column
+//    ALTER TABLE <name> (CHANGE | ADD | DROP | MODIFY) [COLUMN]
+func alterColumnMatch(c *Clause, peekWord string, l *Lexer) bool {
+	switch peekWord {
+	case "change", "add", "drop", "modify":
+		return true
+	}
+	return false
+}
+
 var SqlCreate = []*Clause{
 	{Token: TokenCreate, Lexer: LexCreate},
 	{Token: TokenEngine, Lexer: LexDdlTableStorage, Optional: true},
 	{Token: TokenWith, Lexer: LexJsonOrKeyValue, Optional: true},
 }
 
+var SqlDrop = []*Clause{
+	{Token: TokenDrop, Lexer: LexDrop},
+}
+
+var SqlTruncate = []*Clause{
+	{Token: TokenTruncate, Lexer: LexTruncate},
+}
+
 var SqlDescribe = []*Clause{
-	{Token: TokenDescribe, Lexer: LexColumns},
+	{Token: TokenDescribe, Lexer: LexExplain},
 }
 
 // alternate spelling of Describe
 var SqlDescribeAlt = []*Clause{
-	{Token: TokenDesc, Lexer: LexColumns},
+	{Token: TokenDesc, Lexer: LexExplain},
 }
 
 // Explain is alias of describe
 var SqlExplain = []*Clause{
-	{Token: TokenExplain, Lexer: LexColumns},
+	{Token: TokenExplain, Lexer: LexExplain},
 }
 
 var SqlShow = []*Clause{
@@ -179,34 +328,56 @@ var SqlPrepare = []*Clause{
 	{Token: TokenFrom, Lexer: LexTableReferences},
 }
 
+var SqlExecute = []*Clause{
+	{Token: TokenExecute, Lexer: LexExecute},
+}
+
+var SqlDeallocate = []*Clause{
+	{Token: TokenDeallocate, Lexer: LexDeallocate},
+}
+
 var SqlSet = []*Clause{
-	{Token: TokenSet, Lexer: LexColumns},
+	{Token: TokenSet, Lexer: LexSetClause},
 }
 var SqlUse = []*Clause{
 	{Token: TokenUse, Lexer: LexIdentifier},
 }
 var SqlRollback = []*Clause{
-	{Token: TokenRollback, Lexer: LexEmpty},
+	{Token: TokenRollback, Lexer: LexTransaction},
 }
 var SqlCommit = []*Clause{
-	{Token: TokenCommit, Lexer: LexEmpty},
+	{Token: TokenCommit, Lexer: LexTransaction},
+}
+var SqlBegin = []*Clause{
+	{Token: TokenBegin, Lexer: LexTransaction},
+}
+var SqlStart = []*Clause{
+	{Token: TokenStart, Lexer: LexTransaction},
 }
 
 // SqlDialect is a SQL like dialect
 //
 //    SELECT
+//    WITH
 //    UPDATE
 //    INSERT
 //    UPSERT
 //    DELETE
+//    VALUES (row), (row)
 //
 //    SHOW idenity;
 //    DESCRIBE identity;
-//    PREPARE
+//    PREPARE name FROM 'statement'
+//    EXECUTE name [USING (@var|placeholder) [, ...]]
+//    DEALLOCATE PREPARE name
+//    SET variable = value [, ...]
+//    BEGIN | START TRANSACTION | COMMIT | ROLLBACK
 //
 // ddl
 //    ALTER
-//    CREATE (TABLE|VIEW|CONTINUOUSVIEW|SOURCE)
+//    CREATE (TABLE|VIEW|CONTINUOUSVIEW|SOURCE|[UNIQUE] INDEX)
+//    DROP (TABLE|INDEX)
+//    TRUNCATE [TABLE]
 //
 //  TODO:
 //      CREATE
@@ -214,22 +385,36 @@ var SqlCommit = []*Clause{
 var SqlDialect *Dialect = &Dialect{
 	Statements: []*Clause{
 		{Token: TokenPrepare, Clauses: SqlPrepare},
+		{Token: TokenExecute, Clauses: SqlExecute},
+		{Token: TokenDeallocate, Clauses: SqlDeallocate},
 		{Token: TokenSelect, Clauses: SqlSelect},
+		{Token: TokenWith, Clauses: SqlWith},
 		{Token: TokenUpdate, Clauses: SqlUpdate},
 		{Token: TokenUpsert, Clauses: SqlUpsert},
 		{Token: TokenInsert, Clauses: SqlInsert},
 		{Token: TokenDelete, Clauses: SqlDelete},
+		{Token: TokenValues, Clauses: SqlValues},
 		{Token: TokenCreate, Clauses: SqlCreate},
 		{Token: TokenAlter, Clauses: SqlAlter},
+		{Token: TokenDrop, Clauses: SqlDrop},
+		{Token: TokenTruncate, Clauses: SqlTruncate},
 		{Token: TokenDescribe, Clauses: SqlDescribe},
 		{Token: TokenExplain, Clauses: SqlExplain},
 		{Token: TokenDesc, Clauses: SqlDescribeAlt},
 		{Token: TokenShow, Clauses: SqlShow},
 		{Token: TokenSet, Clauses: SqlSet},
 		{Token: TokenUse, Clauses: SqlUse},
+		{Token: TokenBegin, Clauses: SqlBegin},
+		{Token: TokenStart, Clauses: SqlStart},
 		{Token: TokenRollback, Clauses: SqlRollback},
 		{Token: TokenCommit, Clauses: SqlCommit},
 	},
+	SetOperators:         SqlSetOperators,
+	HashComments:         true,
+	RegexpOperators:      SqlRegexpOperators,
+	TruncateWithoutTable: true,
+	Placeholders:         PlaceholderQuestion | PlaceholderDollar | PlaceholderNamed,
+	// not StrictEquality, existing queries and filters use == for equality
 }
 
 // Handle show statement
@@ -295,7 +480,15 @@ func LexShowClause(l *Lexer) StateFn {
 		l.ConsumeWord(keyWord)
 		l.Emit(TokenTables)
 		return LexShowClause
-	case "columns", "global", "session", "variables", "status",
+	case "databases":
+		l.ConsumeWord(keyWord)
+		l.Emit(TokenDatabases)
+		return LexShowClause
+	case "columns":
+		l.ConsumeWord(keyWord)
+		l.Emit(TokenColumns)
+		return LexShowClause
+	case "global", "session", "variables", "status",
 		"engine", "engines", "procedure", "indexes", "index", "keys",
 		"function", "functions":
 		// TODO:  these should not be identities but tokens?
@@ -307,6 +500,18 @@ func LexShowClause(l *Lexer) StateFn {
 		l.Emit(TokenFrom)
 		l.Push("LexShowClause", LexShowClause)
 		return LexIdentifier
+	case "tag":
+		if l.dialect.Tags {
+			l.ConsumeWord(keyWord)
+			l.Emit(TokenTag)
+			return lexShowTag
+		}
+	case "in":
+		// SHOW TABLES IN db_name is the same as FROM
+		l.ConsumeWord(keyWord)
+		l.Emit(TokenIN)
+		l.Push("LexShowClause", LexShowClause)
+		return LexIdentifier
 	case "like":
 		l.ConsumeWord(keyWord)
 		l.Emit(TokenLike)
@@ -322,9 +527,501 @@ func LexShowClause(l *Lexer) StateFn {
 	case "", ";":
 		return nil
 	}
+	// any other words of the object we are showing are identities
+	l.Push("LexShowClause", LexShowClause)
 	return LexIdentifier
 }
 
+// lexShowTag lexes the rest of a time-series SHOW TAG statement
+//
+//    SHOW TAG KEYS [FROM measurement]
+//    SHOW TAG VALUES [FROM measurement] WITH KEY = tag_key
+//
+func lexShowTag(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	word := strings.ToLower(l.PeekWord())
+
+	switch word {
+	case "keys":
+		l.ConsumeWord(word)
+		l.Emit(TokenKey)
+		return lexShowTag
+	case "values":
+		l.ConsumeWord(word)
+		l.Emit(TokenValues)
+		return lexShowTag
+	case "from":
+		l.ConsumeWord(word)
+		l.Emit(TokenFrom)
+		l.Push("lexShowTag", lexShowTag)
+		return LexIdentifier
+	case "with":
+		l.ConsumeWord(word)
+		l.Emit(TokenWith)
+		return lexShowTag
+	case "key":
+		l.ConsumeWord(word)
+		l.Emit(TokenKey)
+		l.Push("lexShowTag", lexShowTag)
+		return LexExpression
+	}
+	return LexShowClause
+}
+
+// LexSetClause lexes the assignments of a top level SET statement, the
+// variables being set are emitted as TokenVariable.
+//
+//    SET assignment [, assignment ...]
+//
+//    assignment:
+//        NAMES charset_name [COLLATE collation_name]
+//      | CHARACTER SET charset_name
+//      | [GLOBAL | SESSION | LOCAL | PERSIST] variable [= expr]
+//
+//    variable:  name | @name | @@[scope.]name
+//
+func LexSetClause(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	switch l.lastToken.T {
+	case TokenSet, TokenComma:
+		if l.IsEnd() {
+			return l.errorf("expected variable after %s but got EOF", l.lastToken.V)
+		}
+	default:
+		// LexExpression returns here after each term of the value
+		if l.IsEnd() || l.Peek() == ';' {
+			return nil
+		}
+		return LexExpression
+	}
+	word := strings.ToLower(l.PeekWord())
+
+	switch word {
+	case "names":
+		// SET NAMES utf8 COLLATE utf8_general_ci
+		l.ConsumeWord(word)
+		l.Emit(TokenIdentity)
+		l.Push("lexSetNext", lexSetNext)
+		l.Push("lexSetCollate", lexSetCollate)
+		return lexSetValue
+	case "character":
+		// SET CHARACTER SET utf8
+		l.ConsumeWord(word)
+		l.Emit(TokenIdentity)
+		l.SkipWhiteSpaces()
+		if next := strings.ToLower(l.PeekWord()); next != "set" {
+			return l.errorf("expected SET after CHARACTER but got %q", next)
+		}
+		l.ConsumeWord("set")
+		l.Emit(TokenSet)
+		l.Push("lexSetNext", lexSetNext)
+		return lexSetValue
+	case "global", "session", "local", "persist":
+		l.ConsumeWord(word)
+		l.Emit(TokenIdentity)
+		l.SkipWhiteSpaces()
+	}
+	l.Push("lexSetAssign", lexSetAssign)
+	return LexIdentifierOfType(TokenVariable)
+}
+
+// lexSetAssign lexes the = expr after a SET variable, the value is
+// optional:  SET autocommit
+func lexSetAssign(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	if l.IsEnd() {
+		return nil
+	}
+	switch l.Peek() {
+	case '=':
+		l.Next()
+		l.Emit(TokenEqual)
+		// a , in the expression returns to LexSetClause for the next variable
+		return LexExpression
+	case ',', ';':
+		return lexSetNext
+	}
+	return l.errorf("expected = after variable but got %q", l.PeekWord())
+}
+
+// lexSetCollate lexes the optional COLLATE of SET NAMES
+func lexSetCollate(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	word := strings.ToLower(l.PeekWord())
+	if word != "collate" {
+		return nil
+	}
+	l.ConsumeWord(word)
+	l.Emit(TokenIdentity)
+	return lexSetValue
+}
+
+// lexSetNext lexes the , between SET assignments
+func lexSetNext(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	if l.IsEnd() {
+		return nil
+	}
+	switch l.Peek() {
+	case ',':
+		l.Next()
+		l.Emit(TokenComma)
+		return LexSetClause
+	case ';':
+		return nil
+	}
+	return l.errorf("expected , or end of SET but got %q", l.PeekWord())
+}
+
+// lexSetValue lexes a charset or collation name, which may be quoted
+func lexSetValue(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	if l.IsEnd() {
+		return l.errorf("expected value after %s but got EOF", l.lastToken.V)
+	}
+	switch l.Peek() {
+	case '\'', '"':
+		return LexValue
+	}
+	return LexIdentifier
+}
+
+// lexValuesStatement lexes the rows of a standalone VALUES statement, which
+// are lexed the same as the VALUES of an INSERT
+func lexValuesStatement(l *Lexer) StateFn {
+
+	if l.lastToken.T == TokenValues {
+		l.SkipWhiteSpaces()
+		if l.IsEnd() {
+			return l.errorf("expected ( after VALUES but got EOF")
+		}
+		if l.Peek() != '(' {
+			return l.errorf("expected ( after VALUES but got %q", l.PeekWord())
+		}
+	}
+	return LexTableColumns
+}
+
+// LexExecute lexes the name of the prepared statement to EXECUTE and the
+// optional list of variables supplying its parameters.
+//
+//    EXECUTE stmt_name [USING @var_name [, @var_name] ...]
+//    EXECUTE stmt_name USING ?, ?
+//
+func LexExecute(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+
+	switch l.lastToken.T {
+	case TokenExecute:
+		if l.IsEnd() {
+			return l.errorf("expected statement name after EXECUTE but got EOF")
+		}
+		l.Push("LexExecute", LexExecute)
+		return LexIdentifier
+	case TokenUsing, TokenComma:
+		if l.IsEnd() {
+			return l.errorf("expected variable after %s but got EOF", strings.ToUpper(l.lastToken.V))
+		}
+		l.Push("LexExecute", LexExecute)
+		if l.isPlaceholder() {
+			return lexPlaceholder
+		}
+		if l.Peek() != '@' {
+			return l.errorf("expected @variable or placeholder after %s but got %q", strings.ToUpper(l.lastToken.V), l.PeekWord())
+		}
+		return LexIdentifierOfType(TokenVariable)
+	}
+
+	if l.IsEnd() || l.Peek() == ';' {
+		return nil
+	}
+	isArg := l.lastToken.T == TokenVariable || l.lastToken.T == TokenPlaceholder
+	if isArg && l.Peek() == ',' {
+		l.Next()
+		l.Emit(TokenComma)
+		return LexExecute
+	}
+	word := strings.ToLower(l.PeekWord())
+	if l.lastToken.T == TokenIdentity && word == "using" {
+		l.ConsumeWord(word)
+		l.Emit(TokenUsing)
+		return LexExecute
+	}
+	return l.errorf("expected USING or end of EXECUTE but got %q", word)
+}
+
+// LexDeallocate lexes the prepared statement to release
+//
+//    DEALLOCATE PREPARE stmt_name
+//
+func LexDeallocate(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	word := strings.ToLower(l.PeekWord())
+	if word != "prepare" {
+		return l.errorf("expected PREPARE after DEALLOCATE but got %q", word)
+	}
+	l.ConsumeWord(word)
+	l.Emit(TokenPrepare)
+	return LexIdentifier
+}
+
+// LexTransaction lexes the rest of the transaction statements, which is
+// at most the TRANSACTION keyword.  It is required after START.
+//
+//    BEGIN [TRANSACTION]
+//    START TRANSACTION
+//    COMMIT [TRANSACTION]
+//    ROLLBACK [TRANSACTION]
+//
+func LexTransaction(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	word := strings.ToLower(l.PeekWord())
+	if word == "transaction" {
+		l.ConsumeWord(word)
+		l.Emit(TokenTransaction)
+		l.SkipWhiteSpaces()
+	} else if l.lastToken.T == TokenStart {
+		return l.errorf("expected TRANSACTION after START but got %q", word)
+	}
+	if l.IsEnd() || l.Peek() == ';' {
+		return nil
+	}
+	return l.errorf("expected end of %s statement but got %q", strings.ToUpper(l.statement.Token.String()), l.PeekWord())
+}
+
+// LexCommonTableExpr lexes the name of a common table expression up to
+// the opening paren of its sub-query
+//
+//    WITH <name> AS (
+//
+func LexCommonTableExpr(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	if l.IsEnd() {
+		return l.errorf("expected common table expression but got EOF")
+	}
+
+	switch l.lastToken.T {
+	case TokenWith, TokenComma:
+		l.Push("LexCommonTableExpr", LexCommonTableExpr)
+		return LexTableIdentifier
+	case TokenTable:
+		word := strings.ToLower(l.PeekWord())
+		if word != "as" {
+			return l.errorf("expected AS after common table expression name but got %q", word)
+		}
+		l.ConsumeWord(word)
+		l.Emit(TokenAs)
+		return LexCommonTableExpr
+	case TokenAs:
+		if l.Next() != '(' {
+			return l.errorf("expected ( to start common table expression but got %q", l.input[l.start:l.pos])
+		}
+		l.Emit(TokenLeftParenthesis)
+		if !l.isSubQueryStart() {
+			return l.errorf("expected SELECT in common table expression but got %q", l.PeekWord())
+		}
+		l.Push("LexCommonTableExprEnd", LexCommonTableExprEnd)
+		return LexSubStatement
+	}
+	return l.errorf("unexpected token in common table expression %q", l.PeekWord())
+}
+
+// LexCommonTableExprEnd after the closing paren of a common table expression
+// either another expression follows, or the main statement
+//
+//    WITH a AS ( <select_stmt> ) , b AS ( <select_stmt> ) SELECT ...
+//
+func LexCommonTableExprEnd(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	if l.Peek() == ',' {
+		l.Next()
+		l.Emit(TokenComma)
+		return LexCommonTableExpr
+	}
+	// The remainder is a complete statement, so find it from dialect
+	l.stack = l.stack[:0]
+	return LexDialectForStatement
+}
+
+// LexGroupByColumns handles the comma separated list of columns of GROUP BY
+// which may be identities or expressions
+//
+//    GROUP BY <group_col> [, <group_col>]* [WITH ROLLUP]
+//
+//    <group_col>    := ( <identity> | <expr> | <grouping_set> )
+//    <grouping_set> := '(' [<group_col> [, <group_col>]*] ')'
+//
+func LexGroupByColumns(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	if l.IsEnd() {
+		return nil
+	}
+
+	r := l.Peek()
+	//u.Debugf("LexGroupByColumns  r= '%v'  %v", string(r), l.PeekX(10))
+
+	switch r {
+	case ';', ')':
+		return nil
+	case ',':
+		l.Next()
+		l.Emit(TokenComma)
+		return LexGroupByColumns
+	case '(':
+		if l.isGroupingSet() {
+			l.Next()
+			l.Emit(TokenLeftParenthesis)
+			l.Push("LexGroupByColumns", LexGroupByColumns)
+			return lexGroupingSet
+		}
+	}
+
+	word := strings.ToLower(l.PeekWord())
+	if word == "with" && l.isWithRollup() {
+		l.ConsumeWord(word)
+		l.Emit(TokenWith)
+		l.SkipWhiteSpaces()
+		l.ConsumeWord("rollup")
+		l.Emit(TokenRollup)
+		return LexGroupByColumns
+	}
+	if l.isNextKeyword(word) {
+		return nil
+	}
+	l.Push("LexGroupByColumns", LexGroupByColumns)
+	return LexExpression
+}
+
+// lexGroupingSet lexes the columns of a parenthesized grouping set, whose
+// left paren has been consumed, through its right paren
+func lexGroupingSet(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	switch l.Peek() {
+	case ')':
+		l.Next()
+		l.Emit(TokenRightParenthesis)
+		return nil
+	case ',':
+		l.Next()
+		l.Emit(TokenComma)
+		return lexGroupingSet
+	}
+	if l.IsEnd() {
+		return l.errorf("expected ) to end grouping set but got EOF")
+	}
+	l.Push("lexGroupingSet", lexGroupingSet)
+	return LexExpression
+}
+
+// isGroupingSet is true if the ( at the current position starts a grouping
+// set of columns rather than an expression such as (a + b) * 2, which is
+// told by what follows the matching right paren
+func (l *Lexer) isGroupingSet() bool {
+	pos := l.pos
+	l.pos++
+	end := l.matchingParen()
+	l.pos = pos
+	if end < 0 {
+		return false
+	}
+	rest := strings.TrimLeftFunc(l.input[end+1:], unicode.IsSpace)
+	if rest == "" {
+		return true
+	}
+	switch rest[0] {
+	case ',', ';', ')':
+		return true
+	}
+	return isAlpha(rune(rest[0]))
+}
+
+// isWithRollup is true for the WITH ROLLUP of the MySQL super-aggregate
+// rows, rather than a trailing WITH clause such as WITH rollup = true
+func (l *Lexer) isWithRollup() bool {
+	if strings.ToLower(l.wordAfter("with")) != "rollup" {
+		return false
+	}
+	rest := strings.TrimLeftFunc(l.input[l.pos+len("with"):], unicode.IsSpace)
+	rest = strings.TrimLeftFunc(rest[len("rollup"):], unicode.IsSpace)
+	return !strings.HasPrefix(rest, "=")
+}
+
+// LexOffset lexes the row count of OFFSET, with the optional ROWS of the
+// ansi pagination
+//
+//    OFFSET 10 [ROWS | ROW]
+//
+func LexOffset(l *Lexer) StateFn {
+	l.Push("lexRows", lexRows)
+	return LexInteger
+}
+
+// lexRows the optional ROWS or ROW after a row count
+func lexRows(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	switch word := strings.ToLower(l.PeekWord()); word {
+	case "rows", "row":
+		l.ConsumeWord(word)
+		l.Emit(TokenRows)
+	}
+	return nil
+}
+
+// LexFetch lexes the ansi pagination that BI tools use instead of LIMIT,
+// the FETCH keyword has been consumed
+//
+//    [OFFSET n (ROWS | ROW)] FETCH (FIRST | NEXT) [n] (ROWS | ROW) ONLY
+//
+func LexFetch(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	word := strings.ToLower(l.PeekWord())
+
+	switch l.lastToken.T {
+	case TokenFetch:
+		switch word {
+		case "first":
+			l.ConsumeWord(word)
+			l.Emit(TokenFirst)
+		case "next":
+			l.ConsumeWord(word)
+			l.Emit(TokenNext)
+		default:
+			return l.errorf("expected FIRST or NEXT after FETCH but got %q", word)
+		}
+		if isDigit(l.peekRunePast(0)) {
+			l.Push("LexFetch", LexFetch)
+			return LexInteger
+		}
+		return LexFetch
+	case TokenRows:
+		if word != "only" {
+			return l.errorf("expected ONLY after ROWS but got %q", word)
+		}
+		l.ConsumeWord(word)
+		l.Emit(TokenOnly)
+		return nil
+	}
+	switch word {
+	case "rows", "row":
+		l.ConsumeWord(word)
+		l.Emit(TokenRows)
+		return LexFetch
+	}
+	return l.errorf("expected ROWS in FETCH but got %q", word)
+}
+
 // LexLimit clause
 //    LIMIT 1000 OFFSET 100
 //    LIMIT 0, 1000
@@ -347,16 +1044,59 @@ func LexLimit(l *Lexer) StateFn {
 	case ",":
 		l.ConsumeWord(keyWord)
 		l.Emit(TokenComma)
-		return LexNumber
+		return LexInteger
 	default:
-		if isDigit(l.Peek()) {
+		// Only directly after the LIMIT keyword do we require the row count,
+		// any other word is the start of next clause
+		if l.lastToken.T == TokenLimit {
 			l.Push("LexLimit", LexLimit)
-			return LexNumber
+			return LexInteger
 		}
 	}
 	return nil
 }
 
+// LexExplain lexes what follows DESCRIBE, DESC or EXPLAIN, either a table
+// or a whole statement whose tokens follow on from the EXPLAIN
+//
+//    DESCRIBE <table>
+//    EXPLAIN [EXTENDED] <statement>
+//
+func LexExplain(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	word := strings.ToLower(l.PeekWord())
+	if word == "extended" {
+		l.ConsumeWord(word)
+		l.Emit(TokenIdentity)
+		return LexExplain
+	}
+	for _, stmt := range l.dialect.Statements {
+		if stmt.MatchesKeyword(word, l) {
+			return LexDialectForStatement
+		}
+	}
+	return LexColumns
+}
+
+// LexReturning lexes the column list of the rows a DELETE or UPDATE
+// returns, only in dialects that allow it
+//
+//    DELETE FROM t WHERE x = 1 RETURNING id, name
+//
+func LexReturning(l *Lexer) StateFn {
+	if !l.dialect.Returning {
+		return l.errorf("RETURNING is not enabled for this dialect")
+	}
+	l.SkipWhiteSpaces()
+	if l.Peek() == '*' {
+		// RETURNING * is the last clause, there is no keyword after the star
+		l.Next()
+		l.Emit(TokenStar)
+		return nil
+	}
+	return LexSelectClause(l)
+}
+
 // LexCreate allows us to lex the words after CREATE
 //  CREATE [??] <multi_word_identifier> [IF NOT EXISTS] <WITH>
 //
@@ -365,12 +1105,17 @@ func LexCreate(l *Lexer) StateFn {
 	/*
 		CREATE TABLE <identity> [IF NOT EXISTS] [WITH]
 		CREATE SOURCE <identity> [IF NOT EXISTS] [WITH]
+		CREATE [UNIQUE] INDEX <identity> ON <table> (col1, col2)
 	*/
 
 	l.SkipWhiteSpaces()
 	keyWord := strings.ToLower(l.PeekWord())
 	//u.Debugf("LexCreate  r= '%v'", string(keyWord))
 
+	if l.lastToken.T == TokenUnique && keyWord != "index" {
+		return l.errorf("expected INDEX after UNIQUE but got %q", keyWord)
+	}
+
 	switch keyWord {
 	case "table":
 		l.ConsumeWord(keyWord)
@@ -389,10 +1134,103 @@ func LexCreate(l *Lexer) StateFn {
 		l.ConsumeWord(keyWord)
 		l.Emit(TokenContinuousView)
 		return LexIdentifier
+	case "unique":
+		l.ConsumeWord(keyWord)
+		l.Emit(TokenUnique)
+		return LexCreate
+	case "index":
+		l.ConsumeWord(keyWord)
+		l.Emit(TokenIndex)
+		l.Push("LexColumnNames", LexColumnNames)
+		l.Push("lexDdlIndexOn", lexDdlIndexOn)
+		return LexIdentifier
 	default:
 		return nil
 	}
 }
+
+// LexDrop allows us to lex the words after DROP
+//
+//    DROP TABLE [IF EXISTS] <table>
+//    DROP INDEX <identity> ON <table>
+//
+func LexDrop(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	keyWord := strings.ToLower(l.PeekWord())
+
+	switch keyWord {
+	case "table":
+		l.ConsumeWord(keyWord)
+		l.Emit(TokenTable)
+		l.Push("LexIdentifierOfType", LexIdentifierOfType(TokenTable))
+		return lexIfExists
+	case "index":
+		l.ConsumeWord(keyWord)
+		l.Emit(TokenIndex)
+		l.Push("lexDdlIndexOn", lexDdlIndexOn)
+		return LexIdentifier
+	}
+	return l.errorf("expected TABLE or INDEX after DROP but got %q", keyWord)
+}
+
+// LexTruncate allows us to lex the words after TRUNCATE, the TABLE
+// keyword is optional in dialects with TruncateWithoutTable
+//
+//    TRUNCATE [TABLE] <table>
+//
+func LexTruncate(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	keyWord := strings.ToLower(l.PeekWord())
+
+	switch {
+	case keyWord == "table":
+		l.ConsumeWord(keyWord)
+		l.Emit(TokenTable)
+	case !l.dialect.TruncateWithoutTable:
+		return l.errorf("expected TABLE after TRUNCATE but got %q", keyWord)
+	case keyWord == "" || keyWord == ";":
+		return l.errorf("expected table after TRUNCATE but got %q", keyWord)
+	}
+	return LexIdentifierOfType(TokenTable)
+}
+
+// lexDdlIndexOn lexes the table an index belongs to
+//
+//    ON <table>
+//
+func lexDdlIndexOn(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	keyWord := strings.ToLower(l.PeekWord())
+	if keyWord != "on" {
+		return l.errorf("expected ON after index name but got %q", keyWord)
+	}
+	l.ConsumeWord(keyWord)
+	l.Emit(TokenOn)
+	return LexIdentifierOfType(TokenTable)
+}
+
+// lexIfExists lexes an optional IF EXISTS
+func lexIfExists(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	keyWord := strings.ToLower(l.PeekWord())
+	if keyWord != "if" {
+		return nil
+	}
+	l.ConsumeWord(keyWord)
+	l.Emit(TokenIf)
+	l.SkipWhiteSpaces()
+	keyWord = strings.ToLower(l.PeekWord())
+	if keyWord != "exists" {
+		return l.errorf("expected EXISTS after IF but got %q", keyWord)
+	}
+	l.ConsumeWord(keyWord)
+	l.Emit(TokenExists)
+	return nil
+}
+
 func lexNotExists(l *Lexer) StateFn {
 	l.SkipWhiteSpaces()
 	keyWord := strings.ToLower(l.PeekWord())
@@ -472,7 +1310,7 @@ func LexDdlTable(l *Lexer) StateFn {
 			l.Push("LexDdlTable", LexDdlTable)
 			return LexInlineComment
 		}
-		u.Warnf("unhandled comment non inline ")
+		debugf("unhandled comment non inline ")
 	case ';':
 		l.backup()
 		return nil
@@ -501,15 +1339,10 @@ func LexDdlTable(l *Lexer) StateFn {
 			return LexExpressionOrIdentity
 		}
 		if l.isNextKeyword(word) {
-			u.Infof("found keyword? %v ", word)
+			debugf("found keyword? %v ", word)
 			return nil
 		} else {
-			// ensure we don't get into a recursive death spiral here?
-			if len(l.stack) < 10 {
-				l.Push("LexDdlTable", LexDdlTable)
-			} else {
-				u.Errorf("Gracefully refusing to add more LexDdlTable: ")
-			}
+			l.Push("LexDdlTable", LexDdlTable)
 			return LexExpressionOrIdentity
 		}
 	}
@@ -543,6 +1376,9 @@ func LexDdlTableStorage(l *Lexer) StateFn {
 func LexDdlAlterColumn(l *Lexer) StateFn {
 
 	l.SkipWhiteSpaces()
+	if l.IsEnd() {
+		return nil
+	}
 	r := l.Peek()
 
 	//u.Debugf("LexDdlAlterColumn  r= '%v'", string(r))
@@ -567,6 +1403,15 @@ func LexDdlAlterColumn(l *Lexer) StateFn {
 
 	word := strings.ToLower(l.PeekWord())
 	//u.Debugf("looking for operator:  word=%s", word)
+	if word == "column" {
+		// COLUMN is optional after the kind of alteration
+		switch l.lastToken.T {
+		case TokenChange, TokenAdd, TokenDrop, TokenModify:
+			l.ConsumeWord(word)
+			l.Emit(TokenColumn)
+			return LexDdlAlterColumn
+		}
+	}
 	switch word {
 	case "change":
 		l.ConsumeWord(word)
@@ -576,6 +1421,14 @@ func LexDdlAlterColumn(l *Lexer) StateFn {
 		l.ConsumeWord(word)
 		l.Emit(TokenAdd)
 		return LexDdlAlterColumn
+	case "drop":
+		l.ConsumeWord(word)
+		l.Emit(TokenDrop)
+		return LexDdlAlterColumn
+	case "modify":
+		l.ConsumeWord(word)
+		l.Emit(TokenModify)
+		return LexDdlAlterColumn
 	case "after":
 		l.ConsumeWord(word)
 		l.Emit(TokenAfter)
@@ -596,6 +1449,15 @@ func LexDdlAlterColumn(l *Lexer) StateFn {
 		}
 
 	// Below here are Data Types
+	case "int", "integer":
+		l.ConsumeWord(word)
+		l.Emit(TokenTypeInteger)
+		if l.Peek() == '(' {
+			l.Push("LexDdlAlterColumn", l.clauseState())
+			l.Push("LexParenRight", LexParenRight)
+			return LexListOfArgs
+		}
+		return l.clauseState()
 	case "text":
 		l.ConsumeWord(word)
 		l.Emit(TokenTypeText)
@@ -626,12 +1488,7 @@ func LexDdlAlterColumn(l *Lexer) StateFn {
 	//u.LogTracef(u.WARN, "hmmmmmmm")
 	//u.Infof("LexDdlAlterColumn = '%v'", string(r))
 
-	// ensure we don't get into a recursive death spiral here?
-	if len(l.stack) < 100 {
-		l.Push("LexDdlAlterColumn", l.clauseState())
-	} else {
-		u.Errorf("Gracefully refusing to add more LexDdlAlterColumn: ")
-	}
+	l.Push("LexDdlAlterColumn", l.clauseState())
 	return LexExpressionOrIdentity
 }
 
@@ -806,7 +1663,7 @@ func LexDdlTableColumn(l *Lexer) StateFn {
 		}
 	}
 
-	u.Warnf("Did not find anything %s", word)
+	debugf("Did not find anything %s", word)
 	return nil
 }
 
@@ -845,6 +1702,6 @@ func LexEngineKeyValue(l *Lexer) StateFn {
 		l.Push("LexEngineKeyValue", LexEngineKeyValue)
 		return LexExpression
 	}
-	u.Debugf("Did not find key-value? %v", l.PeekX(20))
+	debugf("Did not find key-value? %v", l.PeekX(20))
 	return nil
 }
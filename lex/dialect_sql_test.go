@@ -1,7 +1,10 @@
 package lex
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestLexSqlDescribe(t *testing.T) {
@@ -18,6 +21,47 @@ func TestLexSqlDescribe(t *testing.T) {
 			tv(TokenDesc, "DESC"),
 			tv(TokenIdentity, "mytable"),
 		})
+
+	verifyTokens(t, `EXPLAIN mytable`,
+		[]Token{
+			tv(TokenExplain, "EXPLAIN"),
+			tv(TokenIdentity, "mytable"),
+			tv(TokenEOF, ""),
+		})
+
+	// the explained statement's tokens follow the EXPLAIN
+	verifyTokens(t, `EXPLAIN SELECT a, b FROM users WHERE id = 5 AND x > 2;`,
+		[]Token{
+			tv(TokenExplain, "EXPLAIN"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "users"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "id"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "5"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "x"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "2"),
+			tv(TokenEOS, ";"),
+		})
+	verifyTokens(t, `explain extended delete from t where a = 1`,
+		[]Token{
+			tv(TokenExplain, "explain"),
+			tv(TokenIdentity, "extended"),
+			tv(TokenDelete, "delete"),
+			tv(TokenFrom, "from"),
+			tv(TokenTable, "t"),
+			tv(TokenWhere, "where"),
+			tv(TokenIdentity, "a"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
 }
 
 func TestLexSqlShow(t *testing.T) {
@@ -35,6 +79,245 @@ func TestLexSqlShow(t *testing.T) {
 			TokenFull, TokenTables,
 			TokenFrom, TokenIdentity, TokenLike, TokenValue,
 		})
+
+	verifyTokens(t, `SHOW TABLES;`,
+		[]Token{
+			tv(TokenShow, "SHOW"),
+			tv(TokenTables, "TABLES"),
+			tv(TokenEOS, ";"),
+		})
+	verifyTokens(t, `SHOW TABLES LIKE 'user%'`,
+		[]Token{
+			tv(TokenShow, "SHOW"),
+			tv(TokenTables, "TABLES"),
+			tv(TokenLike, "LIKE"),
+			tv(TokenValue, "user%"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SHOW DATABASES`,
+		[]Token{
+			tv(TokenShow, "SHOW"),
+			tv(TokenDatabases, "DATABASES"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SHOW TABLES IN mydb`,
+		[]Token{
+			tv(TokenShow, "SHOW"),
+			tv(TokenTables, "TABLES"),
+			tv(TokenIN, "IN"),
+			tv(TokenIdentity, "mydb"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SHOW COLUMNS FROM users`,
+		[]Token{
+			tv(TokenShow, "SHOW"),
+			tv(TokenColumns, "COLUMNS"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "users"),
+			tv(TokenEOF, ""),
+		})
+
+	// objects we don't know are identities
+	verifyTokens(t, `SHOW SLAVE STATUS NONBLOCKING`,
+		[]Token{
+			tv(TokenShow, "SHOW"),
+			tv(TokenIdentity, "SLAVE"),
+			tv(TokenIdentity, "STATUS"),
+			tv(TokenIdentity, "NONBLOCKING"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlSet(t *testing.T) {
+	verifyTokens(t, `SET NAMES utf8`,
+		[]Token{
+			tv(TokenSet, "SET"),
+			tv(TokenIdentity, "NAMES"),
+			tv(TokenIdentity, "utf8"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SET NAMES 'utf8mb4' COLLATE 'utf8mb4_general_ci';`,
+		[]Token{
+			tv(TokenSet, "SET"),
+			tv(TokenIdentity, "NAMES"),
+			tv(TokenValue, "utf8mb4"),
+			tv(TokenIdentity, "COLLATE"),
+			tv(TokenValue, "utf8mb4_general_ci"),
+			tv(TokenEOS, ";"),
+		})
+	verifyTokens(t, `SET CHARACTER SET utf8`,
+		[]Token{
+			tv(TokenSet, "SET"),
+			tv(TokenIdentity, "CHARACTER"),
+			tv(TokenSet, "SET"),
+			tv(TokenIdentity, "utf8"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SET autocommit = 1`,
+		[]Token{
+			tv(TokenSet, "SET"),
+			tv(TokenVariable, "autocommit"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SET @@session.sql_mode = 'STRICT'`,
+		[]Token{
+			tv(TokenSet, "SET"),
+			tv(TokenVariable, "@@session.sql_mode"),
+			tv(TokenEqual, "="),
+			tv(TokenValue, "STRICT"),
+			tv(TokenEOF, ""),
+		})
+	// the value is optional
+	verifyTokens(t, `set autocommit`,
+		[]Token{
+			tv(TokenSet, "set"),
+			tv(TokenVariable, "autocommit"),
+			tv(TokenEOF, ""),
+		})
+	// multiple assignments, with scopes and expression values
+	verifyTokens(t, `SET SESSION sql_mode = "x", @a = 1 + 2, NAMES utf8, GLOBAL y = upper("b");`,
+		[]Token{
+			tv(TokenSet, "SET"),
+			tv(TokenIdentity, "SESSION"),
+			tv(TokenVariable, "sql_mode"),
+			tv(TokenEqual, "="),
+			tv(TokenValue, "x"),
+			tv(TokenComma, ","),
+			tv(TokenVariable, "@a"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenPlus, "+"),
+			tv(TokenInteger, "2"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "NAMES"),
+			tv(TokenIdentity, "utf8"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "GLOBAL"),
+			tv(TokenVariable, "y"),
+			tv(TokenEqual, "="),
+			tv(TokenUdfExpr, "upper"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenValue, "b"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOS, ";"),
+		})
+
+	// the SET of an UPDATE is still columns
+	verifyTokenTypes(t, `UPDATE t SET a = 1, b = 2`,
+		[]TokenType{TokenUpdate, TokenTable, TokenSet, TokenIdentity, TokenEqual, TokenInteger,
+			TokenComma, TokenIdentity, TokenEqual, TokenInteger, TokenEOF})
+
+	for _, sql := range []string{`SET`, `SET a = 1,`, `SET a b`, `SET CHARACTER utf8`, `SET NAMES utf8 x`} {
+		_, err := Tokenize(sql)
+		assert.NotEqual(t, nil, err, sql)
+	}
+}
+
+func TestLexSqlTransaction(t *testing.T) {
+	verifyTokens(t, `BEGIN; START TRANSACTION; COMMIT; ROLLBACK;`,
+		[]Token{
+			tv(TokenBegin, "BEGIN"),
+			tv(TokenEOS, ";"),
+			tv(TokenStart, "START"),
+			tv(TokenTransaction, "TRANSACTION"),
+			tv(TokenEOS, ";"),
+			tv(TokenCommit, "COMMIT"),
+			tv(TokenEOS, ";"),
+			tv(TokenRollback, "ROLLBACK"),
+			tv(TokenEOS, ";"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `begin transaction;
+		UPDATE t SET a = 1;
+		commit`,
+		[]Token{
+			tv(TokenBegin, "begin"),
+			tv(TokenTransaction, "transaction"),
+			tv(TokenEOS, ";"),
+			tv(TokenUpdate, "UPDATE"),
+			tv(TokenTable, "t"),
+			tv(TokenSet, "SET"),
+			tv(TokenIdentity, "a"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOS, ";"),
+			tv(TokenCommit, "commit"),
+			tv(TokenEOF, ""),
+		})
+
+	for _, sql := range []string{`COMMIT x`, `ROLLBACK TRANSACTION now`, `START`, `START work`} {
+		_, err := Tokenize(sql)
+		assert.NotEqual(t, nil, err, sql)
+	}
+}
+
+func TestLexSqlValues(t *testing.T) {
+	verifyTokens(t, `VALUES (1, 'a')`,
+		[]Token{
+			tv(TokenValues, "VALUES"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "a"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `VALUES (1,'a'),(2, upper("b")), ((1 + 2) * 3, 'c');`,
+		[]Token{
+			tv(TokenValues, "VALUES"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "a"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "2"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "upper"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenValue, "b"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenPlus, "+"),
+			tv(TokenInteger, "2"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenMultiply, "*"),
+			tv(TokenInteger, "3"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "c"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOS, ";"),
+		})
+	verifyTokens(t, `VALUES (1, 'b', 2.5, true, NULL, -3)`,
+		[]Token{
+			tv(TokenValues, "VALUES"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "b"),
+			tv(TokenComma, ","),
+			tv(TokenFloat, "2.5"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "true"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "NULL"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "-3"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+
+	for _, sql := range []string{`VALUES`, `VALUES 1`, `VALUES (1`} {
+		_, err := Tokenize(sql)
+		assert.NotEqual(t, nil, err, sql)
+	}
 }
 
 func TestLexSqlCreate(t *testing.T) {
@@ -139,3 +422,2142 @@ func TestLexSqlCreateTable(t *testing.T) {
 			tv(TokenValue, "hello"),
 		})
 }
+
+func TestLexSqlIndex(t *testing.T) {
+	verifyTokens(t, `CREATE INDEX idx_name ON users (name, email);`,
+		[]Token{
+			tv(TokenCreate, "CREATE"),
+			tv(TokenIndex, "INDEX"),
+			tv(TokenIdentity, "idx_name"),
+			tv(TokenOn, "ON"),
+			tv(TokenTable, "users"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "name"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "email"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOS, ";"),
+		})
+	verifyTokens(t, "CREATE UNIQUE INDEX idx_email ON `my db`.users (`email`)",
+		[]Token{
+			tv(TokenCreate, "CREATE"),
+			tv(TokenUnique, "UNIQUE"),
+			tv(TokenIndex, "INDEX"),
+			tv(TokenIdentity, "idx_email"),
+			tv(TokenOn, "ON"),
+			tv(TokenTable, "my db`.users"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "email"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `DROP INDEX idx_name ON users`,
+		[]Token{
+			tv(TokenDrop, "DROP"),
+			tv(TokenIndex, "INDEX"),
+			tv(TokenIdentity, "idx_name"),
+			tv(TokenOn, "ON"),
+			tv(TokenTable, "users"),
+			tv(TokenEOF, ""),
+		})
+
+	for _, sql := range []string{
+		`CREATE UNIQUE TABLE users`,
+		`CREATE INDEX idx_name users (name)`,
+		`CREATE INDEX idx_name ON users name`,
+		`CREATE INDEX idx_name ON users (name,)`,
+		`DROP INDEX idx_name`,
+	} {
+		_, err := TokenizeDialect(sql, SqlDialect)
+		assert.NotEqual(t, nil, err, sql)
+	}
+}
+
+func TestLexSqlDropTruncate(t *testing.T) {
+	verifyTokens(t, `DROP TABLE IF EXISTS users;`,
+		[]Token{
+			tv(TokenDrop, "DROP"),
+			tv(TokenTable, "TABLE"),
+			tv(TokenIf, "IF"),
+			tv(TokenExists, "EXISTS"),
+			tv(TokenTable, "users"),
+			tv(TokenEOS, ";"),
+		})
+	verifyTokens(t, `DROP TABLE users`,
+		[]Token{
+			tv(TokenDrop, "DROP"),
+			tv(TokenTable, "TABLE"),
+			tv(TokenTable, "users"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `TRUNCATE TABLE events;`,
+		[]Token{
+			tv(TokenTruncate, "TRUNCATE"),
+			tv(TokenTable, "TABLE"),
+			tv(TokenTable, "events"),
+			tv(TokenEOS, ";"),
+		})
+	verifyTokens(t, `TRUNCATE events`,
+		[]Token{
+			tv(TokenTruncate, "TRUNCATE"),
+			tv(TokenTable, "events"),
+			tv(TokenEOF, ""),
+		})
+
+	// a dialect may require TRUNCATE TABLE
+	ansi := &Dialect{Name: "ansi", Statements: SqlDialect.Statements}
+	ansi.Init()
+	verifyLexerTokens(t, NewLexer(`TRUNCATE TABLE events`, ansi),
+		[]Token{
+			tv(TokenTruncate, "TRUNCATE"),
+			tv(TokenTable, "TABLE"),
+			tv(TokenTable, "events"),
+			tv(TokenEOF, ""),
+		})
+	_, err := TokenizeDialect(`TRUNCATE events`, ansi)
+	assert.NotEqual(t, nil, err)
+
+	for _, tt := range []struct {
+		sql    string
+		column int
+	}{
+		{`DROP users`, 5},
+		{`DROP TABLE IF users`, 14},
+		{`TRUNCATE`, 8},
+	} {
+		_, err := Tokenize(tt.sql)
+		lerr, ok := err.(*LexError)
+		assert.True(t, ok, "expected *LexError for %q got %v", tt.sql, err)
+		if ok {
+			assert.Equal(t, 1, lerr.Line, tt.sql)
+			assert.Equal(t, tt.column, lerr.Column, tt.sql)
+		}
+	}
+}
+
+func TestLexSqlWithCte(t *testing.T) {
+	verifyTokens(t, `WITH cte AS (SELECT a, b FROM t WHERE x > 1) SELECT * FROM cte`,
+		[]Token{
+			tv(TokenWith, "WITH"),
+			tv(TokenTable, "cte"),
+			tv(TokenAs, "AS"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "cte"),
+			tv(TokenEOF, ""),
+		})
+
+	verifyTokens(t, `WITH c1 AS (SELECT a FROM t1 GROUP BY a),
+		c2 AS (SELECT b FROM t2 LIMIT 5)
+	SELECT c1.a FROM c1;`,
+		[]Token{
+			tv(TokenWith, "WITH"),
+			tv(TokenTable, "c1"),
+			tv(TokenAs, "AS"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t1"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenTable, "c2"),
+			tv(TokenAs, "AS"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "b"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t2"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "5"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "c1.a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "c1"),
+			tv(TokenEOS, ";"),
+		})
+
+	// cte referenced in a join, and a join inside the cte
+	verifyTokenTypes(t, `WITH recent AS (SELECT o.uid, o.total FROM orders AS o LEFT JOIN users AS u ON o.uid = u.id)
+	SELECT u.name, r.total FROM users AS u INNER JOIN recent AS r ON u.id = r.uid WHERE r.total > 10`,
+		[]TokenType{TokenWith, TokenTable, TokenAs, TokenLeftParenthesis,
+			TokenSelect, TokenIdentity, TokenComma, TokenIdentity,
+			TokenFrom, TokenIdentity, TokenAs, TokenIdentity,
+			TokenLeft, TokenJoin, TokenIdentity, TokenAs, TokenIdentity,
+			TokenOn, TokenIdentity, TokenEqual, TokenIdentity,
+			TokenRightParenthesis,
+			TokenSelect, TokenIdentity, TokenComma, TokenIdentity,
+			TokenFrom, TokenIdentity, TokenAs, TokenIdentity,
+			TokenInner, TokenJoin, TokenIdentity, TokenAs, TokenIdentity,
+			TokenOn, TokenIdentity, TokenEqual, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenGT, TokenInteger, TokenEOF,
+		})
+
+	toks := lexTokens(`WITH r AS (DELETE FROM x) SELECT * FROM r`)
+	assert.Equal(t, TokenError, toks[len(toks)-1].T)
+}
+
+func TestLexSqlSelectNoFrom(t *testing.T) {
+	// FROM is optional, a select of just expressions
+	verifyTokens(t, `SELECT 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT upper('x')`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "upper"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenValue, "x"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT 1 + 1 AS two, now();`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenInteger, "1"),
+			tv(TokenPlus, "+"),
+			tv(TokenInteger, "1"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "two"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "now"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOS, ";"),
+		})
+	// later clauses are still allowed without a FROM
+	verifyTokens(t, `SELECT @@version_comment LIMIT 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "@@version_comment"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlGroupBy(t *testing.T) {
+	verifyTokens(t, `SELECT a, count(*) FROM t GROUP BY a, lower(b) HAVING count(*) > 1 ORDER BY a`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "lower"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "b"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenHaving, "HAVING"),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenEOF, ""),
+		})
+
+	// any whitespace may separate GROUP and BY
+	verifyTokens(t, `SELECT a FROM t GROUP  BY a LIMIT 10`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenGroupBy, "GROUP  BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "10"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, "SELECT a FROM t GROUP\nBY a;",
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenGroupBy, "GROUP\nBY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenEOS, ";"),
+		})
+
+	// group by inside a sub-query
+	verifyTokens(t, `SELECT * FROM z WHERE x IN (SELECT a FROM t GROUP BY a)`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "z"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenIN, "IN"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlQualifiedStar(t *testing.T) {
+	verifyTokens(t, "SELECT a.*, b.col, `c`.*, [d].* FROM a INNER JOIN b ON a.id = b.id",
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a.*"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b.col"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "c.*"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "d.*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "a"),
+			tv(TokenInner, "INNER"),
+			tv(TokenJoin, "JOIN"),
+			tv(TokenIdentity, "b"),
+			tv(TokenOn, "ON"),
+			tv(TokenIdentity, "a.id"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "b.id"),
+			tv(TokenEOF, ""),
+		})
+
+	// a trailing star is only a wildcard after the dot
+	verifyTokens(t, "SELECT a*2 FROM t",
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenMultiply, "*"),
+			tv(TokenInteger, "2"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+
+	// the star inside a function is not part of the column list
+	verifyTokens(t, "SELECT COUNT(*), u.*, o.total FROM users u",
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "COUNT"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "u.*"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "o.total"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "users"),
+			tv(TokenIdentity, "u"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlAggregateModifiers(t *testing.T) {
+	verifyTokens(t, `SELECT COUNT(DISTINCT user_id), SUM(ALL x), count(*) FROM events`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "COUNT"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenDistinct, "DISTINCT"),
+			tv(TokenIdentity, "user_id"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "SUM"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenAll, "ALL"),
+			tv(TokenIdentity, "x"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "events"),
+			tv(TokenEOF, ""),
+		})
+
+	// only the first word of the args, followed by an expression, is a modifier
+	verifyTokens(t, `SELECT distinct_users, count(distinct(x)), count(distinct) FROM t`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "distinct_users"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenUdfExpr, "distinct"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "x"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "distinct"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlArithmetic(t *testing.T) {
+	verifyTokens(t, `SELECT price * qty AS total, a + b * c - d % 2 FROM orders WHERE total_cents / 100 > 5`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "price"),
+			tv(TokenMultiply, "*"),
+			tv(TokenIdentity, "qty"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "total"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "a"),
+			tv(TokenPlus, "+"),
+			tv(TokenIdentity, "b"),
+			tv(TokenMultiply, "*"),
+			tv(TokenIdentity, "c"),
+			tv(TokenMinus, "-"),
+			tv(TokenIdentity, "d"),
+			tv(TokenModulus, "%"),
+			tv(TokenInteger, "2"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "orders"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "total_cents"),
+			tv(TokenDivide, "/"),
+			tv(TokenInteger, "100"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "5"),
+			tv(TokenEOF, ""),
+		})
+
+	// a minus before a number is its sign unless it follows an operand
+	verifyTokens(t, `SELECT 3 - -4, (a)-1, a * -1.5, f(-2) FROM t WHERE x > -5 AND y BETWEEN -2 AND 2`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenInteger, "3"),
+			tv(TokenMinus, "-"),
+			tv(TokenInteger, "-4"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "a"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenMinus, "-"),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "a"),
+			tv(TokenMultiply, "*"),
+			tv(TokenFloat, "-1.5"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "f"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "-2"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "-5"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "y"),
+			tv(TokenBetween, "BETWEEN"),
+			tv(TokenInteger, "-2"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenInteger, "2"),
+			tv(TokenEOF, ""),
+		})
+
+	// a CASE expression is an operand too
+	verifyTokens(t, `SELECT CASE WHEN a THEN 1 ELSE 2 END -1 AS x FROM t`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenCase, "CASE"),
+			tv(TokenWhen, "WHEN"),
+			tv(TokenIdentity, "a"),
+			tv(TokenThen, "THEN"),
+			tv(TokenInteger, "1"),
+			tv(TokenElse, "ELSE"),
+			tv(TokenInteger, "2"),
+			tv(TokenEnd, "END"),
+			tv(TokenMinus, "-"),
+			tv(TokenInteger, "1"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "x"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlBitwise(t *testing.T) {
+	verifyTokens(t, `SELECT id << 8 | shard, a ^ b, c >> 2 FROM t WHERE perms & 4 = 4 OR q || r`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "id"),
+			tv(TokenLeftShift, "<<"),
+			tv(TokenInteger, "8"),
+			tv(TokenBitOr, "|"),
+			tv(TokenIdentity, "shard"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "a"),
+			tv(TokenBitXor, "^"),
+			tv(TokenIdentity, "b"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "c"),
+			tv(TokenRightShift, ">>"),
+			tv(TokenInteger, "2"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "perms"),
+			tv(TokenBitAnd, "&"),
+			tv(TokenInteger, "4"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "4"),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenIdentity, "q"),
+			tv(TokenOr, "||"),
+			tv(TokenIdentity, "r"),
+			tv(TokenEOF, ""),
+		})
+
+	// shifts next to the comparisons that share their first rune
+	verifyTokenTypes(t, `SELECT a FROM t WHERE c<<2>1 AND x>>1 >= 2 AND y <= 3 AND z < 4 AND w <> 5`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere,
+			TokenIdentity, TokenLeftShift, TokenInteger, TokenGT, TokenInteger, TokenLogicAnd,
+			TokenIdentity, TokenRightShift, TokenInteger, TokenGE, TokenInteger, TokenLogicAnd,
+			TokenIdentity, TokenLE, TokenInteger, TokenLogicAnd,
+			TokenIdentity, TokenLT, TokenInteger, TokenLogicAnd,
+			TokenIdentity, TokenNE, TokenInteger, TokenEOF,
+		})
+
+	// dialects without bit operators
+	nobits := &Dialect{Name: "nobits", Statements: SqlDialect.Statements, DisableBitwise: true}
+	nobits.Init()
+	_, err := TokenizeDialect(`SELECT a FROM t WHERE perms & 4 = 4`, nobits)
+	assert.NotEqual(t, nil, err)
+	toks, err := TokenizeDialect(`SELECT id << 8 FROM t`, nobits)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, TokenLT, toks[2].T)
+	assert.Equal(t, TokenLT, toks[3].T)
+}
+
+func TestLexSqlNullSafeEqual(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t WHERE a <=> b AND a <= b AND a < b`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "a"),
+			tv(TokenNullSafeEqual, "<=>"),
+			tv(TokenIdentity, "b"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "a"),
+			tv(TokenLE, "<="),
+			tv(TokenIdentity, "b"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "a"),
+			tv(TokenLT, "<"),
+			tv(TokenIdentity, "b"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokenTypes(t, `SELECT a<=>b AS n FROM t WHERE c<=>NULL AND d<=2`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenNullSafeEqual, TokenIdentity, TokenAs, TokenIdentity,
+			TokenFrom, TokenIdentity, TokenWhere, TokenIdentity, TokenNullSafeEqual, TokenNull, TokenLogicAnd,
+			TokenIdentity, TokenLE, TokenInteger, TokenEOF,
+		})
+}
+
+func TestLexSqlWindowFunctions(t *testing.T) {
+	verifyTokens(t, `SELECT rank() OVER (PARTITION BY team ORDER BY score DESC) FROM games`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "rank"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenOver, "OVER"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenPartitionBy, "PARTITION BY"),
+			tv(TokenIdentity, "team"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "score"),
+			tv(TokenDesc, "DESC"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "games"),
+			tv(TokenEOF, ""),
+		})
+	// empty window spec
+	verifyTokens(t, `SELECT count(*) OVER () AS ct FROM t`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenOver, "OVER"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "ct"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+	// two window functions in one select list
+	verifyTokens(t, `SELECT row_number() OVER (ORDER BY a, b ASC) AS rn,
+		sum(x) OVER (PARTITION BY g, h) AS total FROM t WHERE y > 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "row_number"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenOver, "OVER"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
+			tv(TokenAsc, "ASC"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "rn"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "sum"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "x"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenOver, "OVER"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenPartitionBy, "PARTITION BY"),
+			tv(TokenIdentity, "g"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "h"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "total"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "y"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+
+	// over that is not a window is still an identity
+	verifyTokenTypes(t, `SELECT over FROM t`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenEOF})
+
+	for _, sql := range []string{
+		`SELECT rank() OVER (PARTITION BY team`,
+		`SELECT rank() OVER (GROUP BY team) FROM t`,
+	} {
+		_, err := Tokenize(sql)
+		assert.NotEqual(t, nil, err, sql)
+	}
+}
+
+func TestLexSqlExists(t *testing.T) {
+	verifyTokens(t, `SELECT * FROM parent WHERE EXISTS (SELECT 1 FROM child WHERE child.pid = parent.id) AND x = 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "parent"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenExists, "EXISTS"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenInteger, "1"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "child"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "child.pid"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "parent.id"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "x"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+
+	verifyTokens(t, `SELECT * FROM parent WHERE x = 1 OR NOT EXISTS (SELECT 1 FROM child WHERE child.pid = parent.id) AND y = 2`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "parent"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenNegate, "NOT"),
+			tv(TokenExists, "EXISTS"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenInteger, "1"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "child"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "child.pid"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "parent.id"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "y"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "2"),
+			tv(TokenEOF, ""),
+		})
+
+	// inside a parenthesized group, without a space before the paren
+	verifyTokenTypes(t, `SELECT * FROM users WHERE y = 2 OR (x = 1 AND EXISTS(SELECT 1 FROM orders WHERE orders.uid = users.id))`,
+		[]TokenType{TokenSelect, TokenStar, TokenFrom, TokenIdentity, TokenWhere,
+			TokenIdentity, TokenEqual, TokenInteger, TokenLogicOr,
+			TokenLeftParenthesis, TokenIdentity, TokenEqual, TokenInteger, TokenLogicAnd,
+			TokenExists, TokenLeftParenthesis,
+			TokenSelect, TokenInteger, TokenFrom, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenEqual, TokenIdentity,
+			TokenRightParenthesis, TokenRightParenthesis, TokenEOF,
+		})
+
+	// exists() as a function is unchanged
+	verifyTokens(t, `SELECT * FROM p WHERE exists(x)`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "p"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenUdfExpr, "exists"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "x"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlQuantifiedComparison(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t WHERE x > ALL (SELECT b FROM u WHERE u.c = t.c) AND y = 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenGT, ">"),
+			tv(TokenAll, "ALL"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "b"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "u"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "u.c"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "t.c"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "y"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT a FROM t WHERE x = ANY (1, 2, 3)`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenEqual, "="),
+			tv(TokenAny, "ANY"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "2"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "3"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokenTypes(t, `SELECT a FROM t WHERE x <> some(SELECT b FROM u) ORDER BY a`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere, TokenIdentity, TokenNE,
+			TokenSome, TokenLeftParenthesis, TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenRightParenthesis,
+			TokenOrderBy, TokenIdentity, TokenEOF,
+		})
+
+	// not after a comparison they are just names
+	verifyTokenTypes(t, `SELECT a FROM t WHERE any = 1`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere, TokenIdentity, TokenEqual,
+			TokenInteger, TokenEOF,
+		})
+}
+
+func TestLexSqlUnion(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t1 UNION ALL SELECT a FROM t2`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t1"),
+			tv(TokenUnion, "UNION"),
+			tv(TokenAll, "ALL"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t2"),
+			tv(TokenEOF, ""),
+		})
+
+	// three-way, trailing order by / limit belong to the last select
+	verifyTokenTypes(t, `SELECT a FROM t1 UNION SELECT a FROM t2 UNION ALL SELECT b FROM t3 WHERE x = 1 ORDER BY a LIMIT 5`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenUnion,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenUnion, TokenAll,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenEqual, TokenInteger,
+			TokenOrderBy, TokenIdentity, TokenLimit, TokenInteger, TokenEOF,
+		})
+
+	// only a select may follow union
+	toks := lexTokens(`SELECT a FROM t1 UNION DELETE FROM t2`)
+	assert.Equal(t, TokenError, toks[len(toks)-1].T)
+}
+
+func TestLexSqlSetOperators(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t1 EXCEPT SELECT a FROM t2`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t1"),
+			tv(TokenExcept, "EXCEPT"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t2"),
+			tv(TokenEOF, ""),
+		})
+
+	// operators are emitted in the order written, each followed by its select
+	verifyTokenTypes(t, `SELECT a FROM t1 WHERE x = 1 UNION ALL SELECT a FROM t2 EXCEPT SELECT a FROM t3 INTERSECT SELECT a FROM t4 ORDER BY a`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenEqual, TokenInteger,
+			TokenUnion, TokenAll,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenExcept,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenIntersect,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenOrderBy, TokenIdentity, TokenEOF,
+		})
+
+	// minus is only a set operator if the dialect registers it
+	ops := map[string]TokenType{"minus": TokenExcept}
+	for k, v := range SqlSetOperators {
+		ops[k] = v
+	}
+	oracle := &Dialect{Name: "oracle", Statements: SqlDialect.Statements, SetOperators: ops}
+	l := NewLexer(`SELECT a FROM t1 MINUS SELECT a FROM t2`, oracle)
+	verifyLexerTokens(t, l,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t1"),
+			tv(TokenExcept, "MINUS"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t2"),
+			tv(TokenEOF, ""),
+		})
+	// otherwise it is just an alias
+	toks := lexTokens(`SELECT a FROM t1 MINUS SELECT a FROM t2`)
+	assert.Equal(t, TokenIdentity, toks[4].T)
+}
+
+func TestLexSqlSetOperatorsInSubQuery(t *testing.T) {
+	// a set operator inside a sub-query belongs to the sub-query, one after
+	// it to the statement
+	verifyTokenTypes(t, `SELECT a FROM t WHERE a IN (SELECT b FROM u) UNION SELECT c FROM v`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenIN, TokenLeftParenthesis,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenRightParenthesis,
+			TokenUnion,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenEOF,
+		})
+	verifyTokenTypes(t, `SELECT a FROM t WHERE a IN (SELECT b FROM u UNION SELECT c FROM v) ORDER BY a`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenIN, TokenLeftParenthesis,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenUnion,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenRightParenthesis,
+			TokenOrderBy, TokenIdentity, TokenEOF,
+		})
+	verifyTokenTypes(t, `SELECT a FROM t WHERE EXISTS (SELECT b FROM u INTERSECT SELECT c FROM v) AND x = 1`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenExists, TokenLeftParenthesis,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenIntersect,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenRightParenthesis,
+			TokenLogicAnd, TokenIdentity, TokenEqual, TokenInteger, TokenEOF,
+		})
+	verifyTokenTypes(t, `SELECT a FROM t WHERE EXISTS (SELECT b FROM u) UNION ALL SELECT c FROM v`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenExists, TokenLeftParenthesis,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenRightParenthesis,
+			TokenUnion, TokenAll,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenEOF,
+		})
+	verifyTokenTypes(t, `SELECT a FROM t WHERE a > ALL (SELECT b FROM u EXCEPT SELECT c FROM v)`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenGT, TokenAll, TokenLeftParenthesis,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenExcept,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenRightParenthesis, TokenEOF,
+		})
+	verifyTokenTypes(t, `SELECT a FROM t WHERE a IN (SELECT b FROM u WHERE c IN (SELECT d FROM w UNION SELECT e FROM z))`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenIN, TokenLeftParenthesis,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenIN, TokenLeftParenthesis,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenUnion,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenRightParenthesis, TokenRightParenthesis, TokenEOF,
+		})
+	verifyTokenTypes(t, `SELECT a FROM t WHERE (SELECT b FROM u UNION SELECT c FROM v) > 1`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenLeftParenthesis,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenUnion,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenRightParenthesis, TokenGT, TokenInteger, TokenEOF,
+		})
+}
+
+func TestLexSqlCase(t *testing.T) {
+	// searched case
+	verifyTokens(t, `SELECT CASE WHEN score > 90 THEN 'A' WHEN score > 80 THEN 'B' ELSE 'C' END AS grade FROM tests`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenCase, "CASE"),
+			tv(TokenWhen, "WHEN"),
+			tv(TokenIdentity, "score"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "90"),
+			tv(TokenThen, "THEN"),
+			tv(TokenValue, "A"),
+			tv(TokenWhen, "WHEN"),
+			tv(TokenIdentity, "score"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "80"),
+			tv(TokenThen, "THEN"),
+			tv(TokenValue, "B"),
+			tv(TokenElse, "ELSE"),
+			tv(TokenValue, "C"),
+			tv(TokenEnd, "END"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "grade"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "tests"),
+			tv(TokenEOF, ""),
+		})
+
+	// simple case, nested in a function call, and in the where clause
+	verifyTokenTypes(t, `SELECT coalesce(CASE status WHEN 1 THEN 'on' ELSE lower(s) END, 'none') AS st
+		FROM t WHERE CASE WHEN a > 1 THEN b ELSE c END = 'x' AND y = 2`,
+		[]TokenType{TokenSelect, TokenUdfExpr, TokenLeftParenthesis,
+			TokenCase, TokenIdentity,
+			TokenWhen, TokenInteger, TokenThen, TokenValue,
+			TokenElse, TokenUdfExpr, TokenLeftParenthesis, TokenIdentity, TokenRightParenthesis,
+			TokenEnd, TokenComma, TokenValue, TokenRightParenthesis,
+			TokenAs, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenCase, TokenWhen, TokenIdentity, TokenGT, TokenInteger,
+			TokenThen, TokenIdentity, TokenElse, TokenIdentity, TokenEnd,
+			TokenEqual, TokenValue, TokenLogicAnd, TokenIdentity, TokenEqual, TokenInteger, TokenEOF,
+		})
+
+	// case nested in a case, end is only a keyword inside of one
+	verifyTokenTypes(t, `SELECT CASE WHEN a > 1 THEN CASE b WHEN 2 THEN 'x' END ELSE 'y' END, end FROM t`,
+		[]TokenType{TokenSelect, TokenCase, TokenWhen, TokenIdentity, TokenGT, TokenInteger,
+			TokenThen, TokenCase, TokenIdentity, TokenWhen, TokenInteger, TokenThen, TokenValue, TokenEnd,
+			TokenElse, TokenValue, TokenEnd, TokenComma, TokenIdentity,
+			TokenFrom, TokenIdentity, TokenEOF,
+		})
+
+	// missing END is an error at its position
+	l := NewSqlLexer("SELECT CASE WHEN a > 1\n THEN 'x' FROM t")
+	for tok := l.NextToken(); tok.T != TokenEOF && tok.T != TokenError; tok = l.NextToken() {
+	}
+	assert.NotEqual(t, nil, l.Err())
+	lerr, _ := l.Err().(*LexError)
+	assert.Equal(t, 2, lerr.Line)
+	assert.Equal(t, 10, lerr.Column)
+}
+
+func TestLexSqlBetween(t *testing.T) {
+	// string bounds, the and after the high bound is a conjunction
+	verifyTokens(t, `SELECT a FROM t WHERE created BETWEEN '2017-01-01' AND '2017-02-01' AND other_col = 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "created"),
+			tv(TokenBetween, "BETWEEN"),
+			tv(TokenValue, "2017-01-01"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenValue, "2017-02-01"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "other_col"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+
+	// numeric bounds, negated
+	verifyTokenTypes(t, `SELECT a FROM t WHERE score NOT BETWEEN 1.5 AND (10) OR b = 2`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere,
+			TokenIdentity, TokenNegate, TokenBetween, TokenFloat, TokenLogicAnd,
+			TokenLeftParenthesis, TokenInteger, TokenRightParenthesis,
+			TokenLogicOr, TokenIdentity, TokenEqual, TokenInteger, TokenEOF,
+		})
+
+	// the and between the bounds is required
+	l := NewSqlLexer("SELECT a FROM t WHERE x BETWEEN 1 OR 10")
+	for tok := l.NextToken(); tok.T != TokenEOF && tok.T != TokenError; tok = l.NextToken() {
+	}
+	assert.NotEqual(t, nil, l.Err())
+}
+
+func TestLexSqlIsNull(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t WHERE deleted_at IS NULL AND (b IS NOT NULL OR lower(c) is null)`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "deleted_at"),
+			tv(TokenIs, "IS"),
+			tv(TokenNull, "NULL"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "b"),
+			tv(TokenIs, "IS"),
+			tv(TokenNegate, "NOT"),
+			tv(TokenNull, "NULL"),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenUdfExpr, "lower"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "c"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenIs, "is"),
+			tv(TokenNull, "null"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+
+	// a column starting with is, is still a column
+	verifyTokenTypes(t, `SELECT a FROM t WHERE is_admin = 1 AND x IS NOT NULL`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere,
+			TokenIdentity, TokenEqual, TokenInteger, TokenLogicAnd,
+			TokenIdentity, TokenIs, TokenNegate, TokenNull, TokenEOF,
+		})
+}
+
+func TestLexSqlLike(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t WHERE b NOT LIKE 'x%_y' AND c LIKE 'a\_%' ESCAPE '\' OR d NOT IN (1, 2)`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "b"),
+			tv(TokenNegate, "NOT"),
+			tv(TokenLike, "LIKE"),
+			tv(TokenValue, "x%_y"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "c"),
+			tv(TokenLike, "LIKE"),
+			tv(TokenValue, `a\_%`),
+			tv(TokenEscape, "ESCAPE"),
+			tv(TokenValue, `\`),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenIdentity, "d"),
+			tv(TokenNegate, "NOT"),
+			tv(TokenIN, "IN"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "2"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+
+	verifyTokenTypes(t, `SELECT a FROM t WHERE c like "50!%" escape '!'`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere,
+			TokenIdentity, TokenLike, TokenValue, TokenEscape, TokenValue, TokenEOF,
+		})
+
+	// escape must be a single character
+	l := NewSqlLexer("SELECT a FROM t WHERE c LIKE 'a' ESCAPE 'ab'")
+	for tok := l.NextToken(); tok.T != TokenEOF && tok.T != TokenError; tok = l.NextToken() {
+	}
+	assert.NotEqual(t, nil, l.Err())
+}
+
+func TestLexSqlNot(t *testing.T) {
+	verifyTokenTypes(t, `SELECT a FROM t WHERE status NOT IN (1,2,3) AND name NOT LIKE 'a%'`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere,
+			TokenIdentity, TokenNegate, TokenIN, TokenLeftParenthesis,
+			TokenInteger, TokenComma, TokenInteger, TokenComma, TokenInteger, TokenRightParenthesis,
+			TokenLogicAnd, TokenIdentity, TokenNegate, TokenLike, TokenValue, TokenEOF,
+		})
+
+	// leading not, of a grouped expression
+	verifyTokenTypes(t, `SELECT a FROM t WHERE NOT (a = 1 AND b = 2)`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere,
+			TokenNegate, TokenLeftParenthesis,
+			TokenIdentity, TokenEqual, TokenInteger, TokenLogicAnd,
+			TokenIdentity, TokenEqual, TokenInteger,
+			TokenRightParenthesis, TokenEOF,
+		})
+
+	// leading not, of a single predicate and of a group after AND
+	verifyTokenTypes(t, `SELECT a FROM t WHERE NOT a = 1 AND NOT (b > 2 OR c)`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere,
+			TokenNegate, TokenIdentity, TokenEqual, TokenInteger, TokenLogicAnd,
+			TokenNegate, TokenLeftParenthesis,
+			TokenIdentity, TokenGT, TokenInteger, TokenLogicOr, TokenIdentity,
+			TokenRightParenthesis, TokenEOF,
+		})
+
+	// double negation
+	verifyTokenTypes(t, `SELECT a FROM t WHERE NOT (NOT (x = 1)) OR NOT NOT y = 2`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere,
+			TokenNegate, TokenLeftParenthesis, TokenNegate, TokenLeftParenthesis,
+			TokenIdentity, TokenEqual, TokenInteger,
+			TokenRightParenthesis, TokenRightParenthesis,
+			TokenLogicOr, TokenNegate, TokenNegate, TokenIdentity, TokenEqual, TokenInteger, TokenEOF,
+		})
+}
+
+func TestLexSqlRegexp(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t WHERE name REGEXP '^a.*' AND b NOT RLIKE 'x' OR c ~ '^a' AND d !~ 'x'`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "name"),
+			tv(TokenRegexp, "REGEXP"),
+			tv(TokenValue, "^a.*"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "b"),
+			tv(TokenNegate, "NOT"),
+			tv(TokenRegexp, "RLIKE"),
+			tv(TokenValue, "x"),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenIdentity, "c"),
+			tv(TokenRegexp, "~"),
+			tv(TokenValue, "^a"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "d"),
+			tv(TokenNotRegexp, "!~"),
+			tv(TokenValue, "x"),
+			tv(TokenEOF, ""),
+		})
+
+	// a dialect only lexes the spellings it enables
+	mysql := &Dialect{Name: "mysql", Statements: SqlDialect.Statements,
+		RegexpOperators: map[string]TokenType{"regexp": TokenRegexp}}
+	mysql.Init()
+	toks := NewLexer(`SELECT a FROM t WHERE name REGEXP '^a' AND b RLIKE 'x'`, mysql).AppendTokens(nil)
+	assert.Equal(t, TokenRegexp, toks[6].T)
+	assert.Equal(t, TokenIdentity, toks[10].T)
+	assert.Equal(t, "RLIKE", toks[10].V)
+}
+
+func TestLexSqlCast(t *testing.T) {
+	verifyTokens(t, `SELECT CAST(price AS INTEGER) AS p FROM items WHERE cast(code AS VARCHAR(20)) = 'a'`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "CAST"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "price"),
+			tv(TokenAs, "AS"),
+			tv(TokenTypeDef, "INTEGER"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "p"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "items"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenUdfExpr, "cast"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "code"),
+			tv(TokenAs, "AS"),
+			tv(TokenTypeDef, "VARCHAR"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "20"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEqual, "="),
+			tv(TokenValue, "a"),
+			tv(TokenEOF, ""),
+		})
+
+	// nested in another function, multi-word types, and convert
+	verifyTokens(t, `SELECT lower(CAST(id AS UNSIGNED INTEGER)), CONVERT(price, DECIMAL(10,2)) FROM items`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "lower"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenUdfExpr, "CAST"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "id"),
+			tv(TokenAs, "AS"),
+			tv(TokenTypeDef, "UNSIGNED INTEGER"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "CONVERT"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "price"),
+			tv(TokenComma, ","),
+			tv(TokenTypeDef, "DECIMAL"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "10"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "2"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "items"),
+			tv(TokenEOF, ""),
+		})
+
+	// a cast must have a type
+	l := NewSqlLexer("SELECT CAST(a AS ) FROM t")
+	for tok := l.NextToken(); tok.T != TokenEOF && tok.T != TokenError; tok = l.NextToken() {
+	}
+	assert.NotEqual(t, nil, l.Err())
+}
+
+func TestLexSqlCastOperator(t *testing.T) {
+	pg := &Dialect{Name: "postgres", Statements: SqlDialect.Statements, CastOperator: true}
+	pg.Init()
+	verifyLexerTokens(t, NewLexer(`SELECT price::text, a::numeric(10,2) AS b, (x + 1)::int FROM t WHERE created::date > "2020-01-01"`, pg),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "price"),
+			tv(TokenCastOp, "::"),
+			tv(TokenTypeDef, "text"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "a"),
+			tv(TokenCastOp, "::"),
+			tv(TokenTypeDef, "numeric"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "10"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "2"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "b"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "x"),
+			tv(TokenPlus, "+"),
+			tv(TokenInteger, "1"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenCastOp, "::"),
+			tv(TokenTypeDef, "int"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "created"),
+			tv(TokenCastOp, "::"),
+			tv(TokenTypeDef, "date"),
+			tv(TokenGT, ">"),
+			tv(TokenValue, "2020-01-01"),
+			tv(TokenEOF, ""),
+		})
+
+	// on literals, and chained with arithmetic
+	verifyLexerTokens(t, NewLexer(`SELECT total::float / count::float, '5'::int + 1.5::int FROM stats`, pg),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "total"),
+			tv(TokenCastOp, "::"),
+			tv(TokenTypeDef, "float"),
+			tv(TokenDivide, "/"),
+			tv(TokenIdentity, "count"),
+			tv(TokenCastOp, "::"),
+			tv(TokenTypeDef, "float"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "5"),
+			tv(TokenCastOp, "::"),
+			tv(TokenTypeDef, "int"),
+			tv(TokenPlus, "+"),
+			tv(TokenFloat, "1.5"),
+			tv(TokenCastOp, "::"),
+			tv(TokenTypeDef, "int"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "stats"),
+			tv(TokenEOF, ""),
+		})
+
+	// a single colon is still a named placeholder
+	named := &Dialect{Name: "named", Statements: SqlDialect.Statements, CastOperator: true, Placeholders: PlaceholderNamed}
+	named.Init()
+	verifyLexerTokens(t, NewLexer(`SELECT a FROM t WHERE b::text = :name`, named),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "b"),
+			tv(TokenCastOp, "::"),
+			tv(TokenTypeDef, "text"),
+			tv(TokenEqual, "="),
+			tv(TokenPlaceholder, "name"),
+			tv(TokenEOF, ""),
+		})
+
+	// not an operator in dialects without it
+	_, err := Tokenize(`SELECT price::text FROM t`)
+	assert.NotEqual(t, nil, err)
+	assert.True(t, strings.Contains(err.Error(), "cast operator"), "%v", err)
+	_, err = Tokenize(`SELECT a FROM t WHERE created::date > "2020-01-01"`)
+	assert.NotEqual(t, nil, err)
+	assert.True(t, strings.Contains(err.Error(), "cast operator"), "%v", err)
+}
+
+func TestLexSqlTypedLiterals(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t WHERE created > DATE '2017-01-01' AND at < time '10:30:00'`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "created"),
+			tv(TokenGT, ">"),
+			tv(TokenDate, "2017-01-01"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "at"),
+			tv(TokenLT, "<"),
+			tv(TokenTime, "10:30:00"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT TIMESTAMP '2017-01-01 10:30:00' FROM t
+		WHERE ts BETWEEN TIMESTAMP '2017-01-01 00:00:00' AND TIMESTAMP '2017-01-02 00:00:00'`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenTimestamp, "2017-01-01 10:30:00"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "ts"),
+			tv(TokenBetween, "BETWEEN"),
+			tv(TokenTimestamp, "2017-01-01 00:00:00"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenTimestamp, "2017-01-02 00:00:00"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT DATE '2017-01-01' AS d, TIME '10:30:00' FROM t WHERE date = DATE '2017-01-01'`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenDate, "2017-01-01"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "d"),
+			tv(TokenComma, ","),
+			tv(TokenTime, "10:30:00"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "date"),
+			tv(TokenEqual, "="),
+			tv(TokenDate, "2017-01-01"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokenTypes(t, `SELECT a FROM t WHERE d IN (DATE '2017-01-01', DATE '2017-01-02')`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere, TokenIdentity,
+			TokenIN, TokenLeftParenthesis, TokenDate, TokenComma, TokenDate, TokenRightParenthesis, TokenEOF,
+		})
+	verifyTokenTypes(t, `INSERT INTO t (d) VALUES (DATE '2017-01-01')`,
+		[]TokenType{TokenInsert, TokenInto, TokenTable, TokenLeftParenthesis, TokenIdentity, TokenRightParenthesis,
+			TokenValues, TokenLeftParenthesis, TokenDate, TokenRightParenthesis, TokenEOF,
+		})
+
+	// doubled quotes are escaped quotes in the value
+	verifyTokens(t, `SELECT a FROM t WHERE b > TIMESTAMP 'it''s' AND c = 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "b"),
+			tv(TokenGT, ">"),
+			tv(TokenTimestamp, "it's"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "c"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+
+	// without a quoted value they are just names
+	verifyTokens(t, `SELECT date FROM t WHERE date > '2017-01-01'`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "date"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "date"),
+			tv(TokenGT, ">"),
+			tv(TokenValue, "2017-01-01"),
+			tv(TokenEOF, ""),
+		})
+
+	_, err := Tokenize(`SELECT a FROM t WHERE created > DATE '2017-01-01`)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLexSqlInterval(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t WHERE ts > now() - INTERVAL '7' DAY AND b = 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "ts"),
+			tv(TokenGT, ">"),
+			tv(TokenUdfExpr, "now"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenMinus, "-"),
+			tv(TokenInterval, "INTERVAL"),
+			tv(TokenValue, "7"),
+			tv(TokenIntervalUnit, "DAY"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "b"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT date_add(ts, interval 3 hours) FROM t`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "date_add"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "ts"),
+			tv(TokenComma, ","),
+			tv(TokenInterval, "interval"),
+			tv(TokenInteger, "3"),
+			tv(TokenIntervalUnit, "hours"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokenTypes(t, `SELECT INTERVAL 1.5 MINUTE AS m FROM t WHERE ts < now() - INTERVAL '2' MONTH`,
+		[]TokenType{TokenSelect, TokenInterval, TokenFloat, TokenIntervalUnit, TokenAs, TokenIdentity,
+			TokenFrom, TokenIdentity, TokenWhere, TokenIdentity, TokenLT, TokenUdfExpr, TokenLeftParenthesis,
+			TokenRightParenthesis, TokenMinus, TokenInterval, TokenValue, TokenIntervalUnit, TokenEOF,
+		})
+
+	// units not known are identities
+	verifyTokenTypes(t, `SELECT a FROM t WHERE ts > now() - INTERVAL 2 fortnight`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere, TokenIdentity, TokenGT,
+			TokenUdfExpr, TokenLeftParenthesis, TokenRightParenthesis, TokenMinus, TokenInterval, TokenInteger,
+			TokenIdentity, TokenEOF,
+		})
+
+	// without a quantity it is just a name
+	verifyTokenTypes(t, `SELECT interval FROM t WHERE interval > 5`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere, TokenIdentity, TokenGT,
+			TokenInteger, TokenEOF,
+		})
+}
+
+func TestLexSqlSelectInto(t *testing.T) {
+	verifyTokens(t, `SELECT * FROM t INTO OUTFILE '/tmp/x.csv'`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenInto, "INTO"),
+			tv(TokenOutfile, "OUTFILE"),
+			tv(TokenValue, "/tmp/x.csv"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT a INTO @var FROM t WHERE b = 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenInto, "INTO"),
+			tv(TokenVariable, "@var"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "b"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokenTypes(t, `SELECT a FROM t WHERE b = 1 ORDER BY a LIMIT 10 INTO OUTFILE "/tmp/y.csv"`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere, TokenIdentity, TokenEqual,
+			TokenInteger, TokenOrderBy, TokenIdentity, TokenLimit, TokenInteger, TokenInto, TokenOutfile, TokenValue, TokenEOF,
+		})
+
+	// a column named like the keyword is not INTO
+	verifyTokens(t, `SELECT count(*) AS into_count FROM t`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "into_count"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+
+	_, err := Tokenize(`SELECT a FROM t INTO OUTFILE`)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLexSqlColumnAlias(t *testing.T) {
+	verifyTokens(t, "SELECT assets, as1, a AS b, c d, [first name], e as [last name], f AS `g h`, count(*) ct FROM t",
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "assets"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "as1"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "a"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "b"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "c"),
+			tv(TokenIdentity, "d"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "first name"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "e"),
+			tv(TokenAs, "as"),
+			tv(TokenIdentity, "last name"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "f"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "g h"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenIdentity, "ct"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlQualifiedNames(t *testing.T) {
+	verifyTokens(t, "SELECT t.c, db.schema.tbl.col, s.t.*, `t`.c, `my db`.`t`.c FROM catalog.schema.table AS x INNER JOIN `db`.tbl ON x.id = tbl.id",
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "t.c"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "db.schema.tbl.col"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "s.t.*"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "t`.c"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "my db`.`t`.c"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "catalog.schema.table"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "x"),
+			tv(TokenInner, "INNER"),
+			tv(TokenJoin, "JOIN"),
+			tv(TokenIdentity, "db`.tbl"),
+			tv(TokenOn, "ON"),
+			tv(TokenIdentity, "x.id"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "tbl.id"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlILike(t *testing.T) {
+	pg := &Dialect{Name: "postgres", Statements: SqlDialect.Statements, ILike: true}
+	pg.Init()
+	verifyLexerTokens(t, NewLexer(`SELECT a FROM t WHERE name ILIKE 'bob%' AND b NOT ilike '50!%' ESCAPE '!'`, pg),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "name"),
+			tv(TokenILike, "ILIKE"),
+			tv(TokenValue, "bob%"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "b"),
+			tv(TokenNegate, "NOT"),
+			tv(TokenILike, "ilike"),
+			tv(TokenValue, "50!%"),
+			tv(TokenEscape, "ESCAPE"),
+			tv(TokenValue, "!"),
+			tv(TokenEOF, ""),
+		})
+
+	// not a keyword in dialects without it
+	toks := NewSqlLexer(`SELECT a FROM t WHERE name ILIKE 'bob%'`).AppendTokens(nil)
+	assert.Equal(t, TokenIdentity, toks[6].T)
+	assert.Equal(t, "ILIKE", toks[6].V)
+}
+
+func TestLexSqlNullsOrdering(t *testing.T) {
+	pg := &Dialect{Name: "postgres", Statements: SqlDialect.Statements, NullsOrdering: true}
+	pg.Init()
+	verifyLexerTokens(t, NewLexer(`SELECT a FROM t ORDER BY score DESC NULLS LAST, b ASC nulls first, c NULLS LAST LIMIT 10`, pg),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "score"),
+			tv(TokenDesc, "DESC"),
+			tv(TokenNulls, "NULLS"),
+			tv(TokenLast, "LAST"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
+			tv(TokenAsc, "ASC"),
+			tv(TokenNulls, "nulls"),
+			tv(TokenFirst, "first"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "c"),
+			tv(TokenNulls, "NULLS"),
+			tv(TokenLast, "LAST"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "10"),
+			tv(TokenEOF, ""),
+		})
+
+	// a column named nulls is still an identity
+	verifyLexerTokens(t, NewLexer(`SELECT nulls FROM t ORDER BY nulls DESC, nulls NULLS FIRST`, pg),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "nulls"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "nulls"),
+			tv(TokenDesc, "DESC"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "nulls"),
+			tv(TokenNulls, "NULLS"),
+			tv(TokenFirst, "FIRST"),
+			tv(TokenEOF, ""),
+		})
+
+	// not keywords in dialects without it
+	toks := NewSqlLexer(`SELECT a FROM t ORDER BY a NULLS LAST`).AppendTokens(nil)
+	assert.Equal(t, TokenIdentity, toks[6].T)
+	assert.Equal(t, "NULLS", toks[6].V)
+}
+
+func TestLexSqlTags(t *testing.T) {
+	ts := &Dialect{Name: "timeseries", Statements: SqlDialect.Statements, Tags: true}
+	ts.Init()
+	verifyLexerTokens(t, NewLexer(`SELECT value FROM cpu WHERE tag(region) = 'us-east' AND tag_key = 'x' GROUP BY tag(host), tag(region)`, ts),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "value"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "cpu"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenTag, "tag"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "region"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEqual, "="),
+			tv(TokenValue, "us-east"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "tag_key"),
+			tv(TokenEqual, "="),
+			tv(TokenValue, "x"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenTag, "tag"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "host"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenTag, "tag"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "region"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+	verifyLexerTokens(t, NewLexer(`SHOW TAG KEYS FROM cpu`, ts),
+		[]Token{
+			tv(TokenShow, "SHOW"),
+			tv(TokenTag, "TAG"),
+			tv(TokenKey, "KEYS"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "cpu"),
+			tv(TokenEOF, ""),
+		})
+	verifyLexerTokens(t, NewLexer(`SHOW TAG VALUES FROM cpu WITH KEY = host`, ts),
+		[]Token{
+			tv(TokenShow, "SHOW"),
+			tv(TokenTag, "TAG"),
+			tv(TokenValues, "VALUES"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "cpu"),
+			tv(TokenWith, "WITH"),
+			tv(TokenKey, "KEY"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "host"),
+			tv(TokenEOF, ""),
+		})
+
+	// a function in dialects without tags
+	toks := NewSqlLexer(`SELECT a FROM cpu GROUP BY tag(host)`).AppendTokens(nil)
+	assert.Equal(t, TokenUdfExpr, toks[5].T)
+	assert.Equal(t, "tag", toks[5].V)
+}
+
+func TestLexSqlStrictEquality(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t WHERE x == 5 AND y = 6`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenEqualEqual, "=="),
+			tv(TokenInteger, "5"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "y"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "6"),
+			tv(TokenEOF, ""),
+		})
+
+	// the sql dialect accepts == even in StrictMode
+	l := NewSqlLexer(`SELECT a FROM t WHERE x == 5`)
+	l.StrictMode = true
+	l.AppendTokens(nil)
+	assert.Equal(t, nil, l.Err())
+
+	strict := &Dialect{Name: "strict", Statements: SqlDialect.Statements, StrictEquality: true}
+	strict.Init()
+	toks, err := TokenizeDialect(`SELECT a FROM t WHERE y = 6`, strict)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 8, len(toks))
+	_, err = TokenizeDialect(`SELECT a FROM t WHERE x == 5`, strict)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLexSqlTableAlias(t *testing.T) {
+	// aliased, with and without AS, and unaliased tables
+	verifyTokenTypes(t, `SELECT u.name FROM users u WHERE u.id = 1`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenEqual, TokenInteger, TokenEOF,
+		})
+	verifyTokenTypes(t, `SELECT u.name FROM users AS u WHERE u.id = 1`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenAs, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenEqual, TokenInteger, TokenEOF,
+		})
+	verifyTokenTypes(t, `SELECT name FROM users WHERE id = 1`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenEqual, TokenInteger, TokenEOF,
+		})
+
+	// aliases that are the prefix of a keyword
+	verifyTokens(t, `SELECT wh.name FROM users wh WHERE wh.id = 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "wh.name"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "users"),
+			tv(TokenIdentity, "wh"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "wh.id"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokenTypes(t, `SELECT g.a FROM t gro GROUP BY g.a`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenIdentity,
+			TokenGroupBy, TokenIdentity, TokenEOF,
+		})
+	verifyTokenTypes(t, `SELECT j.a FROM t joi INNER JOIN x ON joi.id = x.id LIMIT 1`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenIdentity,
+			TokenInner, TokenJoin, TokenIdentity, TokenOn, TokenIdentity, TokenEqual, TokenIdentity,
+			TokenLimit, TokenInteger, TokenEOF,
+		})
+}
+
+func TestLexSqlRegexpDialects(t *testing.T) {
+	// postgres spells regular expression matches with ~ and friends
+	pg := &Dialect{Name: "postgres", Statements: SqlDialect.Statements,
+		RegexpOperators: map[string]TokenType{
+			"~":   TokenRegexp,
+			"~*":  TokenIRegexp,
+			"!~":  TokenNotRegexp,
+			"!~*": TokenNotIRegexp,
+		}}
+	pg.Init()
+	verifyLexerTokens(t, NewLexer(`SELECT a FROM t WHERE col ~ '^a' AND b ~* 'x' OR c !~* 'y'`, pg),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "col"),
+			tv(TokenRegexp, "~"),
+			tv(TokenValue, "^a"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "b"),
+			tv(TokenIRegexp, "~*"),
+			tv(TokenValue, "x"),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenIdentity, "c"),
+			tv(TokenNotIRegexp, "!~*"),
+			tv(TokenValue, "y"),
+			tv(TokenEOF, ""),
+		})
+
+	// mysql only has the word forms
+	mysql := &Dialect{Name: "mysql", Statements: SqlDialect.Statements,
+		RegexpOperators: map[string]TokenType{"regexp": TokenRegexp, "rlike": TokenRegexp}}
+	mysql.Init()
+	verifyLexerTokens(t, NewLexer(`SELECT a FROM t WHERE col RLIKE '^a'`, mysql),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "col"),
+			tv(TokenRegexp, "RLIKE"),
+			tv(TokenValue, "^a"),
+			tv(TokenEOF, ""),
+		})
+	_, err := TokenizeDialect(`SELECT a FROM t WHERE col ~ '^a'`, mysql)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLexSqlReturning(t *testing.T) {
+	pg := &Dialect{Name: "postgres", Statements: SqlDialect.Statements, Returning: true}
+	pg.Init()
+	verifyLexerTokens(t, NewLexer(`DELETE FROM t WHERE x = 1 RETURNING id, name`, pg),
+		[]Token{
+			tv(TokenDelete, "DELETE"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenTable, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenReturning, "RETURNING"),
+			tv(TokenIdentity, "id"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "name"),
+			tv(TokenEOF, ""),
+		})
+	verifyLexerTokens(t, NewLexer(`UPDATE users SET name = 'bob' WHERE id = 5 RETURNING *;`, pg),
+		[]Token{
+			tv(TokenUpdate, "UPDATE"),
+			tv(TokenTable, "users"),
+			tv(TokenSet, "SET"),
+			tv(TokenIdentity, "name"),
+			tv(TokenEqual, "="),
+			tv(TokenValue, "bob"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "id"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "5"),
+			tv(TokenReturning, "RETURNING"),
+			tv(TokenStar, "*"),
+			tv(TokenEOS, ";"),
+		})
+
+	// the default sql dialect does not return rows
+	_, err := Tokenize(`DELETE FROM t WHERE x = 1 RETURNING id`)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLexSqlPlaceholders(t *testing.T) {
+	// ? are numbered in order within each statement
+	verifyTokens(t, `SELECT a FROM t WHERE id = ? AND b IN (?, ?); DELETE FROM t WHERE id = ?`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "id"),
+			tv(TokenEqual, "="),
+			tv(TokenPlaceholder, "1"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "b"),
+			tv(TokenIN, "IN"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenPlaceholder, "2"),
+			tv(TokenComma, ","),
+			tv(TokenPlaceholder, "3"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOS, ";"),
+			tv(TokenDelete, "DELETE"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenTable, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "id"),
+			tv(TokenEqual, "="),
+			tv(TokenPlaceholder, "1"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT a FROM t WHERE id = $2 AND b IN ($1, 5)`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "id"),
+			tv(TokenEqual, "="),
+			tv(TokenPlaceholder, "2"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "b"),
+			tv(TokenIN, "IN"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenPlaceholder, "1"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "5"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT a FROM t WHERE id = :user_id OR b IN (:b1, :b2)`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "id"),
+			tv(TokenEqual, "="),
+			tv(TokenPlaceholder, "user_id"),
+			tv(TokenLogicOr, "OR"),
+			tv(TokenIdentity, "b"),
+			tv(TokenIN, "IN"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenPlaceholder, "b1"),
+			tv(TokenComma, ","),
+			tv(TokenPlaceholder, "b2"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `INSERT INTO t (a, b) VALUES (?, ?), (?, 'x')`,
+		[]Token{
+			tv(TokenInsert, "INSERT"),
+			tv(TokenInto, "INTO"),
+			tv(TokenTable, "t"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenValues, "VALUES"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenPlaceholder, "1"),
+			tv(TokenComma, ","),
+			tv(TokenPlaceholder, "2"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenPlaceholder, "3"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "x"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+
+	// the style is the Quote of the token
+	toks, err := Tokenize(`UPDATE t SET a = ?, b = $1 WHERE c = :c`)
+	assert.Equal(t, nil, err)
+	quotes := ""
+	for _, tok := range toks {
+		if tok.T == TokenPlaceholder {
+			quotes += string(tok.Quote)
+		}
+	}
+	assert.Equal(t, "?$:", quotes)
+
+	// sub-queries continue the numbering of the statement they are in
+	for sql, want := range map[string]string{
+		`SELECT * FROM (SELECT a FROM u WHERE b = ?) AS s WHERE c = ?`:                   "12",
+		`SELECT a FROM t WHERE x = ? AND y IN (SELECT b FROM u WHERE c = ?) AND z = ?`:   "123",
+		`SELECT a FROM t WHERE x = ? AND EXISTS (SELECT b FROM u WHERE c = ?) AND z = ?`: "123",
+	} {
+		toks, err := Tokenize(sql)
+		assert.Equal(t, nil, err, sql)
+		got := ""
+		for _, tok := range toks {
+			if tok.T == TokenPlaceholder {
+				got += tok.V
+			}
+		}
+		assert.Equal(t, want, got, sql)
+	}
+
+	// only the styles the dialect enables are placeholders
+	mysql := &Dialect{Name: "mysql", Statements: SqlDialect.Statements, Placeholders: PlaceholderQuestion}
+	_, err = TokenizeDialect(`SELECT a FROM t WHERE id = ?`, mysql)
+	assert.Equal(t, nil, err)
+	_, err = TokenizeDialect(`SELECT a FROM t WHERE id = $1`, mysql)
+	assert.NotEqual(t, nil, err)
+	ansi := &Dialect{Name: "ansi", Statements: SqlDialect.Statements}
+	_, err = TokenizeDialect(`SELECT a FROM t WHERE id = ?`, ansi)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLexSqlMultiStatement(t *testing.T) {
+	verifyTokens(t, `SELECT a FROM t1; SELECT b FROM t2 WHERE x = 1;
+		-- the last one
+		SELECT u.c FROM t3 AS u INNER JOIN t4 ON u.id = t4.id;`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t1"),
+			tv(TokenEOS, ";"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "b"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t2"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOS, ";"),
+			tv(TokenCommentSingleLine, "--"),
+			tv(TokenComment, " the last one"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "u.c"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t3"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "u"),
+			tv(TokenInner, "INNER"),
+			tv(TokenJoin, "JOIN"),
+			tv(TokenIdentity, "t4"),
+			tv(TokenOn, "ON"),
+			tv(TokenIdentity, "u.id"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "t4.id"),
+			tv(TokenEOS, ";"),
+			tv(TokenEOF, ""),
+		})
+
+	// statements of different types
+	verifyTokenTypes(t, `UPDATE t SET a = 1; DELETE FROM t WHERE a = 2`,
+		[]TokenType{TokenUpdate, TokenTable, TokenSet, TokenIdentity, TokenEqual, TokenInteger, TokenEOS,
+			TokenDelete, TokenFrom, TokenTable, TokenWhere, TokenIdentity, TokenEqual, TokenInteger, TokenEOF,
+		})
+
+	// a comment between statements, and whitespace after the last one
+	verifyTokenTypes(t, `SELECT a FROM t1; /* next */ SELECT b FROM t2;
+	   `,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenEOS,
+			TokenCommentML, TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenEOS, TokenEOF,
+		})
+
+	// empty statements are skipped
+	verifyTokenTypes(t, `;SELECT a FROM t1;; ; -- none
+	;SELECT b FROM t2;;`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenEOS,
+			TokenCommentSingleLine, TokenComment,
+			TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenEOS, TokenEOF,
+		})
+
+	// unless the dialect rejects them
+	strict := &Dialect{Name: "strict", Statements: SqlDialect.Statements, RejectEmptyStatements: true}
+	_, err := TokenizeDialect(`SELECT a FROM t1; SELECT b FROM t2;`, strict)
+	assert.Equal(t, nil, err)
+	toks, err := TokenizeDialect(`SELECT a FROM t1;; SELECT b FROM t2`, strict)
+	assert.NotEqual(t, nil, err)
+	assert.Equal(t, 5, len(toks))
+}
@@ -0,0 +1,93 @@
+package lex
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// Fingerprint lexes a sql statement and writes it back out with all of
+// its literal values replaced by a ? placeholder, keywords lower cased
+// and whitespace and comments collapsed, identities are left as written.
+// Statements that only differ in their literal values share a fingerprint,
+// which makes it useful for grouping queries in logs.  A list of literals
+// in an IN is collapsed to a single placeholder.
+//
+//    SELECT * FROM t WHERE id = 5 AND name IN ('a','b')
+//    select * from t where id = ? and name in (?)
+//
+// Only the first statement of a multi-statement script is fingerprinted.
+func Fingerprint(sql string) (string, error) {
+
+	l := NewSqlLexer(sql)
+
+	var buf bytes.Buffer
+	var prev TokenType
+	inList := false
+	for {
+		tok := l.NextToken()
+		switch tok.T {
+		case TokenEOF, TokenEOS:
+			return buf.String(), nil
+		case TokenError:
+			if err := l.Err(); err != nil {
+				return "", err
+			}
+			return "", errors.New(tok.V)
+		case TokenComment, TokenCommentML, TokenCommentStart, TokenCommentEnd,
+			TokenCommentSlashes, TokenCommentSingleLine, TokenCommentHash, TokenHint, TokenMysqlVersionComment, TokenNewLine:
+			continue
+		}
+
+		v := tok.V
+		switch tok.T {
+		case TokenValue, TokenValueEscaped, TokenRegex, TokenDuration,
+			TokenBool, TokenFloat, TokenInteger, TokenPlaceholder,
+			TokenDate, TokenTime, TokenTimestamp:
+			v = "?"
+			// typed literals keep their type, date '2017-01-01' -> date ?
+			switch tok.T {
+			case TokenDate:
+				v = "date ?"
+			case TokenTime:
+				v = "time ?"
+			case TokenTimestamp:
+				v = "timestamp ?"
+			}
+			if inList && prev == TokenComma && bytes.HasSuffix(buf.Bytes(), []byte(v+",")) {
+				// in (?, ?, ?)  ->  in (?)
+				buf.Truncate(buf.Len() - 1)
+				prev = TokenValue
+				continue
+			}
+			tok.T = TokenValue
+		case TokenIdentity, TokenTable, TokenVariable:
+			// identities may be case sensitive, and are written as is,
+			// quoted ones with their quotes
+			switch tok.Quote {
+			case 0:
+			case '[':
+				v = "[" + v + "]"
+			default:
+				v = string(tok.Quote) + v + string(tok.Quote)
+			}
+		case TokenLeftParenthesis:
+			inList = prev == TokenIN
+		case TokenRightParenthesis:
+			inList = false
+		default:
+			v = strings.ToLower(v)
+		}
+
+		switch {
+		case buf.Len() == 0:
+		case tok.T == TokenComma, tok.T == TokenRightParenthesis:
+		case prev == TokenLeftParenthesis:
+		case prev == TokenUdfExpr && tok.T == TokenLeftParenthesis:
+		default:
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(v)
+		prev = tok.T
+	}
+}
@@ -0,0 +1,79 @@
+package lex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		sql  string
+		same string
+		fp   string
+	}{
+		{
+			`SELECT * FROM t WHERE id = 5`,
+			`select *   -- comment
+			 FROM t
+			 WHERE id = 99;`,
+			`select * from t where id = ?`,
+		},
+		{
+			`SELECT name FROM users WHERE email = 'bob@example.com' AND score > 1.5`,
+			`SELECT name FROM users WHERE email = "alice@example.com" AND score > 20`,
+			`select name from users where email = ? and score > ?`,
+		},
+		{
+			`SELECT count(*) AS ct FROM users WHERE id IN (1, 2, 3) AND b IN (x, y)`,
+			`SELECT COUNT(*) AS ct FROM users WHERE id IN ('a') AND b IN (x, y)`,
+			`select count(*) as ct from users where id in (?) and b in (x, y)`,
+		},
+		{
+			`SELECT a FROM t WHERE id = ? AND b IN (?, ?)`,
+			`SELECT a FROM t WHERE id = $1 AND b IN (:b1, 'x')`,
+			`select a from t where id = ? and b in (?)`,
+		},
+		{
+			`SELECT a FROM t WHERE d > DATE '2020-01-01' AND ts IN (TIMESTAMP '2020-01-01 10:00:00', TIMESTAMP '2020-01-02 10:00:00')`,
+			`SELECT a FROM t WHERE d > date '2021-06-30' AND ts IN (TIMESTAMP '2021-01-01 00:00:00')`,
+			`select a from t where d > date ? and ts in (timestamp ?)`,
+		},
+		{
+			`SELECT /*+ shard(us-east) */ a FROM t WHERE id = 1`,
+			`/*+ shard(us-west) */ SELECT a FROM t WHERE id = 2`,
+			`select a from t where id = ?`,
+		},
+	}
+	for _, tt := range tests {
+		fp, err := Fingerprint(tt.sql)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, tt.fp, fp)
+		fp, err = Fingerprint(tt.same)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, tt.fp, fp)
+	}
+
+	// identities are case sensitive, so not the same query
+	fp1, _ := Fingerprint(`SELECT Name FROM users`)
+	fp2, _ := Fingerprint(`SELECT name FROM users`)
+	assert.NotEqual(t, fp1, fp2)
+
+	// and written as they were, quoted or not
+	for _, tt := range []struct {
+		sql string
+		fp  string
+	}{
+		{"SELECT `User`.`Name`, [deleted] FROM `User` WHERE `Id` = 5", "select `User`.`Name`, [deleted] from `User` where `Id` = ?"},
+		{"SELECT name FROM user", "select name from user"},
+		{"UPDATE Users SET Name = 'x' WHERE Id = 1", "update Users set Name = ? where Id = ?"},
+		{"DELETE FROM Users WHERE Id = 1", "delete from Users where Id = ?"},
+	} {
+		fp, err := Fingerprint(tt.sql)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, tt.fp, fp)
+	}
+
+	_, err := Fingerprint(`SELECT a FROM t WHERE b = 'unterminated`)
+	assert.NotEqual(t, nil, err)
+}
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -27,6 +28,8 @@ func init() {
 	}
 }
 
+// debugf logs when tracing, callers on hot paths should check Trace first
+// as the args escape (and allocate) even when it is off
 func debugf(f string, args ...interface{}) {
 	if Trace {
 		u.DoLog(3, u.DEBUG, fmt.Sprintf(f, args...))
@@ -45,6 +48,9 @@ var (
 	IdentityQuoting             = []byte{'[', '`'}       // no single quote around identities bc effing mysql uses single quote for string literals
 )
 
+// DefaultMaxDepth is the MaxDepth of new lexers
+const DefaultMaxDepth = 1000
+
 const (
 	eof       = -1
 	decDigits = "0123456789"
@@ -63,13 +69,14 @@ type NamedStateFn struct {
 // Creates a new lexer for the input string
 //
 func NewLexer(input string, dialect *Dialect) *Lexer {
-	// Three tokens of buffering is sufficient for all state functions.
 	l := &Lexer{
 		input:   input,
 		state:   LexDialectForStatement,
-		tokens:  make(chan Token, 3),
+		tokens:  make([]Token, 0, 3),
 		stack:   make([]NamedStateFn, 0, 10),
 		dialect: dialect,
+
+		MaxDepth: DefaultMaxDepth,
 	}
 	if len(dialect.IdentityQuoting) > 0 {
 		l.identityRunes = dialect.IdentityQuoting
@@ -93,6 +100,80 @@ func NewSqlLexer(input string) *Lexer {
 	return NewLexer(input, SqlDialect)
 }
 
+// Tokenize lexes all of the statements in sql using the SqlDialect and
+// returns their tokens, statements are separated by their TokenEOS, the
+// final TokenEOF is not included.  If lexing fails the tokens up to the
+// error are returned along with the *LexError.
+func Tokenize(sql string) ([]Token, error) {
+	return TokenizeDialect(sql, SqlDialect)
+}
+
+// TokenizeDialect is Tokenize for the given dialect.
+func TokenizeDialect(sql string, dialect *Dialect) ([]Token, error) {
+	l := NewLexer(sql, dialect)
+	toks := make([]Token, 0, len(sql)/4)
+	for {
+		tok := l.NextToken()
+		switch tok.T {
+		case TokenEOF:
+			return toks, nil
+		case TokenError:
+			return toks, l.Err()
+		}
+		toks = append(toks, tok)
+	}
+}
+
+// LexError describes why, and where in the input, lexing failed
+type LexError struct {
+	Msg    string
+	Pos    int // byte offset into input
+	Line   int // 1 based line number
+	Column int // 1 based column number
+}
+
+func (e *LexError) Error() string {
+	return fmt.Sprintf("%s at line %d column %d", e.Msg, e.Line, e.Column)
+}
+
+// Stats summarizes the tokens a Lexer has produced so far, to find which
+// statements are expensive to lex
+type Stats struct {
+	Tokens   int               // count of tokens emitted
+	Counts   map[TokenType]int // count of tokens emitted of each type
+	MaxDepth int               // deepest nesting of the lexer's state stack
+	Err      bool              // lexing failed
+}
+
+// lexStats are the Stats of a Lexer as it lexes, the counts of each type
+// are kept in a short slice rather than a map as it is cheaper to update
+type lexStats struct {
+	tokens   int
+	counts   []typeCount
+	maxDepth int
+	err      bool
+}
+
+type typeCount struct {
+	t  TokenType
+	ct int
+}
+
+func (s *lexStats) count(t TokenType) {
+	s.tokens++
+	for i := range s.counts {
+		if s.counts[i].t == t {
+			s.counts[i].ct++
+			return
+		}
+	}
+	if s.counts == nil {
+		// enough for the distinct types of most statements
+		s.counts = make([]typeCount, 0, 16)
+	}
+	s.counts = append(s.counts, typeCount{t, 1})
+}
+
 // Lexer holds the state of the lexical scanning.
 //
 //  Holds a *Dialect* which gives much of the
@@ -101,28 +182,59 @@ func NewSqlLexer(input string) *Lexer {
 // many-generations removed from that Based on the lexer from the "text/template" package.
 // See http://www.youtube.com/watch?v=HxaD_trXwRE
 type Lexer struct {
-	input         string     // the string being scanned
-	state         StateFn    // the next lexing function to enter
-	identityRunes []byte     // List of legal identity escape bytes
-	pos           int        // current position in the input
-	start         int        // start position of this token
-	width         int        // width of last rune read from input
-	line          int        // Line we are currently on
-	linepos       int        // Position of start of current line
-	lastToken     Token      // last token we emitted
-	tokens        chan Token // channel of scanned tokens we output on
-	doubleDelim   bool       // flag for tags starting with double braces
-	dialect       *Dialect   // Dialect is the syntax-rules for all statement-types of this language
-	statement     *Clause    // Statement type we are lexing
-	curClause     *Clause    // Current clause we are lexing, we descend, ascend, iter()
-	descent       *Clause    // Clause we have descended to
+	input         string   // the string being scanned
+	state         StateFn  // the next lexing function to enter
+	identityRunes []byte   // List of legal identity escape bytes
+	pos           int      // current position in the input
+	start         int      // start position of this token
+	width         int      // width of last rune read from input
+	line          int      // Line we are currently on
+	linepos       int      // Position of start of current line
+	lastToken     Token    // last token we emitted
+	tokens        []Token  // scanned tokens not yet returned by NextToken
+	tokenPos      int      // position in tokens of next token to return
+	doubleDelim   bool     // flag for tags starting with double braces
+	dialect       *Dialect // Dialect is the syntax-rules for all statement-types of this language
+	statement     *Clause  // Statement type we are lexing
+	curClause     *Clause  // Current clause we are lexing, we descend, ascend, iter()
+	descent       *Clause  // Clause we have descended to
 	peekedWordPos int
 	peekedWord    string
 	lastQuoteMark byte
+	err           *LexError              // first error encountered, lexing stops there
+	caseStack     []TokenType            // last keyword lexed of each (nested) CASE expression
+	placeholders  int                    // count of ? placeholders in this statement
+	versionEnd    int                    // position of the */ ending the open /*! version comment, or 0
+	comments      []string               // comments not yet attached to a token, in CommentTrivia mode
+	commentStart  int                    // position of the --, // or # starting the current comment
+	trivia        map[triviaKey][]string // comments before each token, in CommentTrivia mode
+	stats         lexStats
 
 	// Due to nested Expressions and evaluation this allows us to descend/ascend
 	// during lex, using push/pop to add and remove states needing evaluation
 	stack []NamedStateFn
+
+	// StrictMode makes input the lexer does not understand, such as an
+	// unbalanced ) or text left over once a statement ends, a TokenError
+	// with its position, instead of being skipped or lexed as best it can.
+	// Select clauses out of order, ORDER BY x WHERE y, are also an error.
+	StrictMode bool
+
+	// CommentTrivia collects comments, including their delimiters, instead
+	// of emitting them as tokens, so the token stream is not interrupted by
+	// them.  Comments(tok) returns those before tok, comments at the end of
+	// the input are before the TokenEOF.
+	CommentTrivia bool
+
+	// MaxDepth is how deep the stack of pending states may grow, which
+	// limits how deeply expressions and parens may nest.  Each level of
+	// parens uses a few states, deeper input is a lex error.
+	MaxDepth int
+
+	// MaxInputLen is the longest input the lexer will lex, longer input is
+	// a lex error without being scanned.  0 (the default) is no limit,
+	// services lexing untrusted input should set it.
+	MaxInputLen int
 }
 
 func (l *Lexer) init() {
@@ -130,6 +242,82 @@ func (l *Lexer) init() {
 	l.ReverseTrim()
 }
 
+// Reset re-uses this lexer (and its token and state buffers) for new input
+// of the same dialect, so high volume callers lexing many statements do not
+// need to allocate a new Lexer for each one.
+func (l *Lexer) Reset(input string) {
+	*l = Lexer{
+		input:         input,
+		state:         LexDialectForStatement,
+		identityRunes: l.identityRunes,
+		tokens:        l.tokens[:0],
+		stack:         l.stack[:0],
+		dialect:       l.dialect,
+		stats:         lexStats{counts: l.stats.counts[:0]},
+		StrictMode:    l.StrictMode,
+		CommentTrivia: l.CommentTrivia,
+		MaxDepth:      l.MaxDepth,
+		MaxInputLen:   l.MaxInputLen,
+	}
+	l.init()
+}
+
+// Clone returns a copy of this lexer at its current position, so a parser
+// may speculatively lex ahead on one copy and discard it.  The copies share
+// the (immutable) input and dialect, but each has its own position, pending
+// tokens and state stack, so advancing one does not move the other.
+func (l *Lexer) Clone() *Lexer {
+	c := *l
+	c.tokens = append(make([]Token, 0, cap(l.tokens)), l.tokens...)
+	c.stack = append(make([]NamedStateFn, 0, cap(l.stack)), l.stack...)
+	c.caseStack = append([]TokenType(nil), l.caseStack...)
+	c.comments = append([]string(nil), l.comments...)
+	if l.trivia != nil {
+		c.trivia = make(map[triviaKey][]string, len(l.trivia))
+		for k, v := range l.trivia {
+			c.trivia[k] = v
+		}
+	}
+	c.stats.counts = append([]typeCount(nil), l.stats.counts...)
+	return &c
+}
+
+// AppendTokens lexes the rest of the current statement appending the tokens
+// to buf, including the final EOS, EOF or Error token, and returns the
+// extended buffer. Callers may pass buf[:0] back in to avoid re-allocating.
+func (l *Lexer) AppendTokens(buf []Token) []Token {
+	for {
+		tok := l.NextToken()
+		buf = append(buf, tok)
+		switch tok.T {
+		case TokenEOF, TokenEOS, TokenError:
+			return buf
+		}
+	}
+}
+
+// Stats of the tokens lexed so far, since the lexer was created or Reset
+func (l *Lexer) Stats() Stats {
+	s := Stats{
+		Tokens:   l.stats.tokens,
+		Counts:   make(map[TokenType]int, len(l.stats.counts)),
+		MaxDepth: l.stats.maxDepth,
+		Err:      l.stats.err,
+	}
+	for _, tc := range l.stats.counts {
+		s.Counts[tc.t] = tc.ct
+	}
+	return s
+}
+
+// Err returns the *LexError if lexing failed, or nil
+func (l *Lexer) Err() error {
+	if l.err == nil {
+		return nil
+	}
+	return l.err
+}
+
 func (l *Lexer) ErrMsg(t Token, msg string) error {
 	raw := l.RawInput()
 	if len(raw) == l.pos {
@@ -158,32 +346,64 @@ func (l *Lexer) NextToken() Token {
 
 	for {
 		//u.Debugf("token: start=%v  pos=%v  peek5=%s", l.start, l.pos, l.PeekX(5))
-		select {
-		case token := <-l.tokens:
+		if l.tokenPos < len(l.tokens) {
+			token := l.tokens[l.tokenPos]
+			l.tokenPos++
+			if l.tokenPos == len(l.tokens) {
+				// all handed out, re-use the slice
+				l.tokens = l.tokens[:0]
+				l.tokenPos = 0
+			}
 			return token
-		default:
-			if l.state == nil && len(l.stack) > 0 {
-				l.state = l.pop()
-			} else if l.state == nil {
-				return Token{T: TokenEOF, V: ""}
+		}
+		if l.err != nil {
+			// there is no lexing past the first error
+			l.state = nil
+		} else if l.pos == 0 && l.MaxInputLen > 0 && len(l.input) > l.MaxInputLen {
+			l.errorf("input is too long, %d bytes is more than the max of %d", len(l.input), l.MaxInputLen)
+			continue
+		}
+		if l.state == nil && len(l.stack) > 0 {
+			l.state = l.pop()
+		} else if l.state == nil {
+			if l.StrictMode && l.err == nil && l.unlexed() {
+				continue
 			}
-			l.state = l.state(l)
+			eof := Token{T: TokenEOF, V: "", Pos: l.pos}
+			l.attachComments(eof)
+			return eof
 		}
+		l.state = l.state(l)
+	}
+}
+
+// unlexed is true if, with lexing done, there is more than whitespace
+// left over in the input, which is an error in StrictMode
+func (l *Lexer) unlexed() bool {
+	l.SkipWhiteSpaces()
+	if l.tokenPos < len(l.tokens) {
+		// the end of a /*! version comment
+		return true
+	}
+	if l.IsEnd() {
+		return false
 	}
+	l.errorf("unexpected %q", l.PeekX(1))
+	return true
 }
 
 func (l *Lexer) Push(name string, state StateFn) {
-	debugf("push %d %v", len(l.stack)+1, name)
-	if len(l.stack) < 250 {
-		l.stack = append(l.stack, NamedStateFn{name, state})
-	} else {
-		out := ""
-		if len(l.input) > 200 {
-			out = strings.Replace(l.input[0:199], "\n", " ", -1)
-		} else {
-			out = strings.Replace(l.input, "\n", " ", -1)
-		}
-		u.LogThrottle(u.WARN, 10, "Gracefully refusing to add more LexExpression: %s", out)
+	if Trace {
+		debugf("push %d %v", len(l.stack)+1, name)
+	}
+	if len(l.stack) >= l.MaxDepth {
+		// the state that pushed will carry on, but NextToken stops at the error
+		l.errorf("expression is nested too deeply, max depth is %d", l.MaxDepth)
+		return
+	}
+	l.stack = append(l.stack, NamedStateFn{name, state})
+	if len(l.stack) > l.stats.maxDepth {
+		l.stats.maxDepth = len(l.stack)
 	}
 }
 
@@ -194,7 +414,9 @@ func (l *Lexer) pop() StateFn {
 	li := len(l.stack) - 1
 	last := l.stack[li]
 	l.stack = l.stack[0:li]
-	debugf("popped item off stack:  %d %v", len(l.stack)+1, last.Name)
+	if Trace {
+		debugf("popped item off stack:  %d %v", len(l.stack)+1, last.Name)
+	}
 	return last.StateFn
 }
 
@@ -298,7 +520,7 @@ func (l *Lexer) PeekWord2() string {
 	for i := skipWs; i < len(l.input)-l.pos; i++ {
 		r, _ := utf8.DecodeRuneInString(l.input[l.pos+i:])
 		if unicode.IsSpace(r) || !IsIdentifierRune(r) {
-			u.Infof("hm:   '%v' word='%s' %v", l.input[l.pos:l.pos+i], word, l.input[l.pos:l.pos+i] == word)
+			debugf("hm:   '%v' word='%s' %v", l.input[l.pos:l.pos+i], word, l.input[l.pos:l.pos+i] == word)
 			return word
 		} else {
 			word = word + string(r)
@@ -387,12 +609,12 @@ func (l *Lexer) IsComment() bool {
 	r := l.Peek()
 	switch r {
 	case '#':
-		return true
+		return l.dialect.HashComments
 	case '/', '-':
 		// continue on, might be, check 2nd character
 		cv := l.PeekX(2)
 		switch cv {
-		case "//":
+		case "//", "/*":
 			return true
 		case "--":
 			return true
@@ -405,23 +627,96 @@ func (l *Lexer) IsComment() bool {
 
 // emit passes an token back to the client.
 func (l *Lexer) Emit(t TokenType) {
-	debugf("emit: %s  '%s'  stack=%v start=%d pos=%d", t, l.input[l.start:l.pos], len(l.stack), l.start, l.pos)
+	l.EmitValue(t, l.input[l.start:l.pos])
+}
+
+// EmitValue passes a token back to the client using the given value
+// instead of the raw input, for tokens whose value differs from the
+// text they were lexed from (ie quoted identities).
+func (l *Lexer) EmitValue(t TokenType, v string) {
+	if Trace {
+		debugf("emit: %s  '%s'  stack=%v start=%d pos=%d", t, v, len(l.stack), l.start, l.pos)
+	}
 	// switch t {
 	// case TokenEOF, TokenError:
 	// 	u.WarnT(10)
 	// }
+	if l.CommentTrivia && l.commentTrivia(t) {
+		l.start = l.pos
+		return
+	}
 	// We are going to use 1 based indexing (not 0 based) for lines
 	// because humans don't think that way
 	if l.lastQuoteMark != 0 {
-		l.lastToken = Token{T: t, V: l.input[l.start:l.pos], Quote: l.lastQuoteMark, Line: l.line + 1, Column: l.columnNumber(), Pos: l.pos}
+		l.lastToken = Token{T: t, V: v, Quote: l.lastQuoteMark, Line: l.line + 1, Column: l.columnNumber(), Pos: l.pos}
 		l.lastQuoteMark = 0
 	} else {
-		l.lastToken = Token{T: t, V: l.input[l.start:l.pos], Line: l.line + 1, Column: l.columnNumber(), Pos: l.pos}
+		l.lastToken = Token{T: t, V: v, Line: l.line + 1, Column: l.columnNumber(), Pos: l.pos}
 	}
-	l.tokens <- l.lastToken
+	l.attachComments(l.lastToken)
+	l.tokens = append(l.tokens, l.lastToken)
+	l.stats.count(t)
 	l.start = l.pos
 }
 
+// commentTrivia collects the comment being emitted as t, if it is one, to
+// be attached to the next token instead
+func (l *Lexer) commentTrivia(t TokenType) bool {
+	switch t {
+	case TokenCommentSingleLine, TokenCommentSlashes, TokenCommentHash:
+		// the text of the comment is emitted next
+		l.commentStart = l.start
+	case TokenComment:
+		l.comments = append(l.comments, l.input[l.commentStart:l.pos])
+	case TokenCommentML:
+		l.comments = append(l.comments, l.input[l.start-len("/*"):l.pos+len("*/")])
+	default:
+		return false
+	}
+	return true
+}
+
+// triviaKey identifies the token comments are attached to, a token ends
+// at a position, but a zero width one (ie EOF) may end where the token
+// before it does
+type triviaKey struct {
+	t   TokenType
+	pos int
+}
+
+// attachComments attaches the comments not yet attached to a token to tok
+func (l *Lexer) attachComments(tok Token) {
+	if len(l.comments) == 0 {
+		return
+	}
+	if l.trivia == nil {
+		l.trivia = make(map[triviaKey][]string)
+	}
+	l.trivia[triviaKey{tok.T, tok.Pos}] = l.comments
+	l.comments = nil
+}
+
+// mergeTrivia takes the comments of the sub-query lexed by sub, those
+// before its end are before the right paren ending it
+func (l *Lexer) mergeTrivia(sub *Lexer) {
+	for k, v := range sub.trivia {
+		if k.t == TokenEOF {
+			l.comments = append(l.comments, v...)
+			continue
+		}
+		if l.trivia == nil {
+			l.trivia = make(map[triviaKey][]string)
+		}
+		l.trivia[k] = v
+	}
+}
+
+// Comments returns the comments, including their delimiters, before tok
+// in the input, when lexed in CommentTrivia mode.
+func (l *Lexer) Comments(tok Token) []string {
+	return l.trivia[triviaKey{tok.T, tok.Pos}]
+}
+
 // ignore skips over the pending input before this point.
 func (l *Lexer) ignore() {
 	l.start = l.pos
@@ -497,7 +792,19 @@ func (l *Lexer) columnNumber() int {
 // error returns an error token and terminates the scan by passing
 // back a nil pointer that will be the next state, terminating l.nextToken.
 func (l *Lexer) errorf(format string, args ...interface{}) StateFn {
-	l.tokens <- Token{T: TokenError, V: fmt.Sprintf(format, args...)}
+	if l.err != nil {
+		// only the first error is reported
+		return nil
+	}
+	l.err = &LexError{Msg: fmt.Sprintf(format, args...), Pos: l.pos,
+		Line: l.line + 1, Column: l.columnNumber()}
+	l.lastToken = Token{T: TokenError, V: l.err.Msg, Line: l.err.Line, Column: l.err.Column, Pos: l.err.Pos}
+	l.tokens = append(l.tokens, l.lastToken)
+	l.stats.count(TokenError)
+	l.stats.err = true
+	// there is no recovering from an error, so don't pop back into
+	// any of the pending states
+	l.stack = l.stack[:0]
 	return nil
 }
 
@@ -512,6 +819,51 @@ func (l *Lexer) SkipWhiteSpaces() {
 	}
 	l.backup()
 	l.ignore()
+	if l.dialect.MysqlVersionComments && l.lexVersionComment() {
+		l.SkipWhiteSpaces()
+		return
+	}
+	if l.err == nil && l.IsComment() {
+		// comments may appear anywhere whitespace may, they are emitted but
+		// the token before them is still the last token for context
+		last := l.lastToken
+		if next := LexComment(l); next != nil {
+			next(l)
+		}
+		if l.err == nil {
+			l.lastToken = last
+		}
+		l.SkipWhiteSpaces()
+	}
+}
+
+// lexVersionComment emits the start or end of a MySQL version gated
+// comment if it is next, the sql inside of it is lexed as usual
+//
+//    /*!40101 SET NAMES utf8 */     -> TokenMysqlVersionComment "40101", ..., TokenCommentEnd
+//
+func (l *Lexer) lexVersionComment() bool {
+	if l.versionEnd > 0 && l.pos == l.versionEnd {
+		l.ConsumeWord("*/")
+		l.Emit(TokenCommentEnd)
+		l.versionEnd = 0
+		return true
+	}
+	if l.versionEnd > 0 || !strings.HasPrefix(l.input[l.pos:], "/*!") {
+		return false
+	}
+	end := strings.Index(l.input[l.pos:], "*/")
+	if end < 0 {
+		// unterminated, left for the comment lexer to error on
+		return false
+	}
+	l.versionEnd = l.pos + end
+	l.ignoreWord("/*!")
+	for isDigit(l.Peek()) {
+		l.Next()
+	}
+	l.Emit(TokenMysqlVersionComment)
+	return true
 }
 
 // Skips white space characters in the input, returns bool
@@ -561,6 +913,13 @@ func (l *Lexer) match(matchTo string, skip int) bool {
 			skip--
 			continue
 		}
+		if matchRune == ' ' {
+			// multi-word keyword, allow any whitespace between words
+			if !l.skipKeywordSpace() {
+				return false
+			}
+			continue
+		}
 
 		nr := l.Next()
 		//u.Debugf("rune=%s n=%s   %v  %v", string(matchRune), string(nr), matchRune != nr, unicode.ToLower(nr) != matchRune)
@@ -573,8 +932,8 @@ func (l *Lexer) match(matchTo string, skip int) bool {
 		return true
 	}
 	// If we finished looking for the match word, and the next item is not
-	// whitespace, it means we failed
-	if !isWhiteSpace(l.Peek()) {
+	// whitespace or the end of statement, it means we failed
+	if r := l.Peek(); !isWhiteSpace(r) && r != ';' {
 		return false
 	}
 	//u.Debugf("Found match():  %v", matchTo)
@@ -587,15 +946,21 @@ func (l *Lexer) match(matchTo string, skip int) bool {
 //
 // NOTE:  this assumes the @val you are trying to match against is LOWER CASE
 func (l *Lexer) tryMatch(matchTo string) bool {
-	i := 0
+	pos, line, linepos := l.pos, l.line, l.linepos
 	//u.Debugf("tryMatch:  start='%v'", l.PeekWord())
 	for _, matchRune := range matchTo {
-		i++
+		if matchRune == ' ' {
+			// multi-word keywords such as "group by" may have any amount
+			// of whitespace (including new-lines) between the words
+			if !l.skipKeywordSpace() {
+				l.pos, l.line, l.linepos = pos, line, linepos
+				return false
+			}
+			continue
+		}
 		nextRune := l.Next()
 		if unicode.ToLower(nextRune) != matchRune {
-			for ; i > 0; i-- {
-				l.backup()
-			}
+			l.pos, l.line, l.linepos = pos, line, linepos
 			//u.Warnf("not found:  %v:%v", string(nextRune), matchTo)
 			return false
 		}
@@ -604,13 +969,55 @@ func (l *Lexer) tryMatch(matchTo string) bool {
 	return true
 }
 
+// consume the whitespace between words of a multi-word keyword, returns
+// false if there was none
+func (l *Lexer) skipKeywordSpace() bool {
+	found := false
+	for r := l.Next(); isWhiteSpace(r); r = l.Next() {
+		found = true
+		if r == '\n' {
+			l.line++
+			l.linepos = l.pos
+		}
+	}
+	l.backup()
+	return found
+}
+
+// non-consuming check for a multi-word keyword such as "group by", allowing
+// any whitespace between the words.  Expects keyword to be lower case.
+func (l *Lexer) peekMultiWord(keyword string) bool {
+	pos := l.pos
+	for i := 0; len(keyword) > 0; i++ {
+		word := keyword
+		if sp := strings.IndexByte(keyword, ' '); sp >= 0 {
+			word, keyword = keyword[:sp], keyword[sp+1:]
+		} else {
+			keyword = ""
+		}
+		if i > 0 {
+			wsStart := pos
+			for pos < len(l.input) && isWhiteSpace(rune(l.input[pos])) {
+				pos++
+			}
+			if pos == wsStart {
+				return false
+			}
+		}
+		if len(l.input)-pos < len(word) || !strings.EqualFold(l.input[pos:pos+len(word)], word) {
+			return false
+		}
+		pos += len(word)
+	}
+	return true
+}
+
 // Emits an error token and terminates the scan
 // by passing back a nil ponter that will be the next state
 // terminating lexer.next function
-func (l *Lexer) errorToken(format string, args ...interface{}) StateFn {
-	//fmt.Sprintf(format, args...)
-	l.Emit(TokenError)
-	return nil
+func (l *Lexer) errorToken(msg string) StateFn {
+	// messages often include raw input, which may contain a %
+	return l.errorf("%s", msg)
 }
 
 // non-consuming isExpression, expressions are defined by
@@ -665,6 +1072,25 @@ func (l *Lexer) isNextKeyword(peekWord string) bool {
 	kwMaybe := strings.ToLower(peekWord)
 	//u.Debugf("isNextKeyword?  '%s'   len:%v", kwMaybe, len(l.statement.Clauses))
 
+	// join keywords are only reserved while lexing sources, so a
+	// column named left in the select list is still a column
+	if l.isJoinKeyword(kwMaybe) && l.inSourceClause() {
+		return true
+	}
+	if _, ok := l.dialect.SetOperators[kwMaybe]; ok {
+		return true
+	}
+	if len(l.caseStack) > 0 {
+		switch kwMaybe {
+		case "when", "then", "else", "end":
+			return true
+		}
+	}
+
+	if l.StrictMode && l.earlierClause(kwMaybe) != nil {
+		return true
+	}
+
 	clause := l.curClause.next
 	if clause == nil {
 		clause = l.curClause.parent
@@ -679,8 +1105,11 @@ func (l *Lexer) isNextKeyword(peekWord string) bool {
 		//clause = l.statement.Clauses[i]
 		//u.Infof("clause: %+v", clause)
 		//u.Debugf("clause next keyword?    peek=%s cname=%q keyword=%v multi?%v children?%v", kwMaybe, clause.Name, clause.keyword, clause.multiWord, len(clause.Clauses))
-		if clause.keyword == kwMaybe || (clause.multiWord && strings.ToLower(l.PeekX(len(clause.fullWord))) == clause.fullWord) {
-			//u.Infof("return true:  %v", strings.ToLower(l.PeekX(len(clause.fullWord))))
+		if clause.keyword == kwMaybe || (clause.multiWord && l.peekMultiWord(clause.fullWord)) {
+			return true
+		}
+		if clause.KeywordMatcher != nil && clause.Token != 0 && clause.MatchesKeyword(kwMaybe, l) {
+			// a keyword only in context, ie SKIP 10
 			return true
 		}
 		// TODO:  allow clauses to reserve keywords, or sub-clause
@@ -698,6 +1127,48 @@ func (l *Lexer) isNextKeyword(peekWord string) bool {
 	return false
 }
 
+// earlierClause finds the clause keyword, such as the WHERE of
+//
+//    SELECT a FROM t ORDER BY x WHERE y = 1
+//
+// of a clause that comes before the current one in the statement, which
+// is out of order.  Returns nil if word is not one.
+func (l *Lexer) earlierClause(word string) *Clause {
+	for c := l.curClause; c != nil; c = c.parent {
+		for p := c.prev; p != nil; p = p.prev {
+			if p.Token.IsClauseKeyword() && p.MatchesKeyword(word, l) {
+				return p
+			}
+		}
+		if p := c.parent; p != nil && p.parent != nil && p.Token.IsClauseKeyword() && p.MatchesKeyword(word, l) {
+			return p
+		}
+	}
+	return nil
+}
+
+// isJoinKeyword is the word one that starts, or continues, a join
+func (l *Lexer) isJoinKeyword(word string) bool {
+	switch word {
+	case "join", "on", "left", "right", "full", "cross":
+		return true
+	}
+	return false
+}
+
+// inSourceClause are we lexing the sources (FROM, JOIN ... ON) portion
+// of a statement
+func (l *Lexer) inSourceClause() bool {
+	if l.curClause == nil {
+		return false
+	}
+	switch l.curClause.keyword {
+	case "from", "on":
+		return true
+	}
+	return l.curClause.KeywordMatcher != nil
+}
+
 // non-consuming isIdentity
 // Identities are non-numeric string values that are not quoted
 func (l *Lexer) isIdentity() bool {
@@ -739,7 +1210,7 @@ func (l *Lexer) LexMatchSkip(tok TokenType, skip int, fn StateFn) StateFn {
 		l.Emit(tok)
 		return fn
 	}
-	u.Error("unexpected token", tok)
+	debugf("unexpected token %v", tok)
 	return l.errorToken("Unexpected token:" + l.current())
 }
 
@@ -763,13 +1234,13 @@ func (l *Lexer) clauseState() StateFn {
 		}
 		return l.curClause.Lexer
 	}
-	u.Debugf("curClause? %v", l.curClause)
+	debugf("curClause? %v", l.curClause)
 	//u.Debugf("curClause: %v", len(l.curClause.Clauses))
-	u.Warnf("empty lex fn? %v", l.PeekX(10))
+	debugf("empty lex fn? %v", l.PeekX(10))
 	return emptyLexFn
 }
 
-var emptyLexFn = func(*Lexer) StateFn { u.Debugf("empty statefun"); return nil }
+var emptyLexFn = func(*Lexer) StateFn { debugf("empty statefun"); return nil }
 
 // matches expected tokentype emitting the token on success
 // and returning passed state function.
@@ -781,7 +1252,7 @@ func LexMatchClosure(tok TokenType, nextFn StateFn) StateFn {
 			l.Emit(tok)
 			return nextFn
 		}
-		u.Warnf("unexpected token: %v   peek:%s", tok, l.PeekX(20))
+		debugf("unexpected token: %v   peek:%s", tok, l.PeekX(20))
 		return l.errorToken("Unexpected token:" + l.current())
 	}
 }
@@ -793,14 +1264,29 @@ func LexMatchClosure(tok TokenType, nextFn StateFn) StateFn {
 func LexDialectForStatement(l *Lexer) StateFn {
 
 	l.SkipWhiteSpaces()
+	if l.IsEnd() {
+		// empty or whitespace/comment only input, there is no statement
+		return nil
+	}
 
 	r := l.Peek()
 
 	switch r {
 	case '/', '-', '#':
+		if r == '#' && !l.dialect.HashComments {
+			return l.errorf("unexpected '#', # comments are not enabled for this dialect")
+		}
 		// ensure we have consumed all initial pre-statement comments
 		l.Push("LexDialectForStatement", LexDialectForStatement)
 		return LexComment(l)
+	case ';':
+		// empty statement
+		if l.dialect.RejectEmptyStatements {
+			return l.errorf("empty statement, unexpected ';'")
+		}
+		l.Next()
+		l.ignore()
+		return LexDialectForStatement
 	default:
 		peekWord := strings.ToLower(l.PeekWord())
 		for _, stmt := range l.dialect.Statements {
@@ -854,6 +1340,9 @@ func LexStatement(l *Lexer) StateFn {
 
 	switch r {
 	case '/', '-', '#':
+		if r == '#' && !l.dialect.HashComments {
+			return l.errorf("unexpected '#', # comments are not enabled for this dialect")
+		}
 		// ensure we have consumed all comments
 		l.Push("LexStatement", LexStatement)
 		return LexComment(l)
@@ -909,7 +1398,7 @@ func LexStatement(l *Lexer) StateFn {
 				} else if clause.Optional {
 					return l.lexIfMatch(clause.Token, clause.Lexer)
 				} else {
-					return LexMatchClosure(clause.Token, clause.Lexer)
+					return l.LexMatchSkip(clause.Token, 0, clause.Lexer)
 				}
 			} else if clause.MatchesKeyword(peekWord, l) {
 				//u.Debugf("nil lexer but matches? repeat?%v isrepeat?%v  name=%q", clause.Repeat, repeat, clause.Name)
@@ -987,6 +1476,9 @@ func LexStatement(l *Lexer) StateFn {
 
 	// Correctly reached EOF.
 	if l.pos > l.start {
+		if l.StrictMode {
+			return l.errorf("unexpected %q", l.input[l.start:l.pos])
+		}
 		// What is this?
 		l.Emit(TokenRaw)
 	}
@@ -1018,14 +1510,17 @@ func LexValue(l *Lexer) StateFn {
 		return l.errorToken("expected value but got EOF")
 	}
 	rune := l.Next()
-	typ := TokenValue
 
 	//u.Debugf("LexValue: rune=%v  peek:%v", string(rune), l.PeekX(10))
 
 	switch rune {
 	case ')':
+		if l.StrictMode {
+			l.backup()
+			return l.errorf("unexpected ) expected a value")
+		}
 		// this is a mistake and should not happen
-		u.Warnf("why did we get paren? going to panic")
+		debugf("why did we get paren? going to panic")
 		//panic("should not have paren")
 		return nil
 	case '[':
@@ -1037,62 +1532,11 @@ func LexValue(l *Lexer) StateFn {
 		return LexJsonArray
 	case '\'', '"':
 		// quoted string, allows escaping
-		firstRune := rune
 		l.ignore() // consume the quote mark
-		previousEscaped := rune == '\\'
-		for rune = l.Next(); ; rune = l.Next() {
-
-			//u.Debugf("LexValue rune=%v  end?%v  prevEscape?%v  quote=%s", string(rune), rune == eof, previousEscaped, string(firstRune))
-			//(rune == '\'' || rune == '"') &&
-			if rune == firstRune {
-
-				if !previousEscaped {
-					if !l.IsEnd() {
-						rune = l.Next()
-						// check for escaped quote mark
-						if rune == firstRune {
-							typ = TokenValueEscaped
-							previousEscaped = false
-						} else {
-							// since we read lookahead after escape/quote that ends the string
-							l.backup()
-							// for single quote which is not part of the value
-							l.backup()
-							l.lastQuoteMark = byte(firstRune)
-							l.Emit(typ)
-							// now ignore that single quote
-							l.Next()
-							l.ignore()
-							return nil
-						}
-					} else {
-						// at the very end
-						l.backup()
-						l.lastQuoteMark = byte(firstRune)
-						l.Emit(typ)
-						l.Next()
-						return nil
-					}
-				} else {
-					// Was escaped   \"
-					typ = TokenValueEscaped
-					previousEscaped = false
-				}
-
-			} else if rune == eof {
-				return l.errorToken("reached end without finding end for quoted value")
-			} else if rune == '\\' {
-				previousEscaped = true
-			} else if rune == 0 {
-				return l.errorToken("string value was not quoted")
-			} else if previousEscaped {
-				// if we are on next rune, then previous \ was NOT an escape, cancel
-				previousEscaped = false
-			}
-		}
+		return lexQuotedValue(l, rune)
 	default:
 		if rune == '*' {
-			u.LogTracef(u.WARN, "why are we having a star here? %v", l.PeekX(10))
+			debugf("why are we having a star here? %v", l.PeekX(10))
 		}
 		// Non-Quoted String?   Should this be a numeric?   or date or what?  duration?  what kinds are valid?
 		//  A:   numbers
@@ -1112,6 +1556,101 @@ func LexValue(l *Lexer) StateFn {
 	}
 }
 
+// lexQuotedValue scans a quoted string whose opening quote has been
+// consumed, emitting the raw value between the quotes.  A quote inside
+// the value is escaped by doubling it, or with a backslash, in which
+// case the token is a TokenValueEscaped, see Token.UnEscapedValue.
+//
+//  'it''s'                 -> it''s
+//  "she said ""hi"""       -> she said ""hi""
+//  "she said \"hi\""       -> she said \"hi\"
+//
+func lexQuotedValue(l *Lexer, quote rune) StateFn {
+	if l.dialect.BackslashEscapes {
+		return lexQuotedValueBackslash(l, quote)
+	}
+	escaped, ok := l.scanQuotedValue(quote)
+	if !ok {
+		return l.errorToken("reached end without finding end for quoted value")
+	}
+	typ := TokenValue
+	if escaped {
+		typ = TokenValueEscaped
+	}
+	// emit the value without the closing quote (always a single
+	// byte), then consume it
+	l.pos--
+	l.lastQuoteMark = byte(quote)
+	l.Emit(typ)
+	l.pos++
+	l.ignore()
+	return nil
+}
+
+// scanQuotedValue consumes the rest of a quoted string whose opening quote
+// has been consumed, through its closing quote.  escaped is true if a quote
+// in the value is escaped by doubling it or with a backslash, ok is false
+// if the input ends first.
+func (l *Lexer) scanQuotedValue(quote rune) (escaped, ok bool) {
+	for {
+		switch r := l.Next(); {
+		case r == eof:
+			return escaped, false
+		case r == '\\' && l.Peek() == quote, r == quote && l.Peek() == quote:
+			escaped = true
+			l.Next()
+		case r == quote:
+			return escaped, true
+		}
+	}
+}
+
+// lexQuotedValueBackslash scans a quoted string for dialects with
+// BackslashEscapes, the emitted value has its escape sequences decoded.
+//
+//  'line1\nline2'      -> line1<newline>line2
+//  'it\'s'             -> it's
+//  'it''s'             -> it's
+//  'c:\\temp'          -> c:\temp
+//
+func lexQuotedValueBackslash(l *Lexer, quote rune) StateFn {
+	val := make([]rune, 0, 16)
+	for {
+		switch r := l.Next(); {
+		case r == eof:
+			return l.errorToken("reached end without finding end for quoted value")
+		case r == '\\':
+			switch esc := l.Next(); esc {
+			case eof:
+				return l.errorToken("reached end without finding end for quoted value")
+			case 'n':
+				val = append(val, '\n')
+			case 't':
+				val = append(val, '\t')
+			case '\\', '\'', '"':
+				val = append(val, esc)
+			default:
+				// not a recognized escape, keep it as is
+				val = append(val, r, esc)
+			}
+		case r == quote && l.Peek() == quote:
+			val = append(val, quote)
+			l.Next()
+		case r == quote:
+			// emit the value without the closing quote (always a single
+			// byte), then consume it
+			l.pos--
+			l.lastQuoteMark = byte(quote)
+			l.EmitValue(TokenValue, string(val))
+			l.pos++
+			l.ignore()
+			return nil
+		default:
+			val = append(val, r)
+		}
+	}
+}
+
 // lex a regex:   first character must be a /
 //
 //  /^stats\./i
@@ -1179,6 +1718,15 @@ func LexExpressionOrIdentity(l *Lexer) StateFn {
 		return LexExpressionOrIdentity
 	}
 	// u.Debugf("LexExpressionOrIdentity identity?%v expr?%v %v peek5='%v'", l.isIdentity(), l.isExpr(), string(l.Peek()), string(l.PeekX(5)))
+	if l.isPlaceholder() {
+		return lexPlaceholder(l)
+	}
+	if l.isTypedLiteral() {
+		return lexTypedLiteral(l)
+	}
+	if l.isInterval() {
+		return lexInterval
+	}
 	// Expressions end in Parens:     LOWER(item)
 	if l.isExpr() {
 		return lexExpressionIdentifier(l)
@@ -1192,6 +1740,135 @@ func LexExpressionOrIdentity(l *Lexer) StateFn {
 	}
 }
 
+// afterOperand is true if the last token ends an operand, so a following
+// - is the binary minus rather than the sign of a number
+//
+//    a - 5    (a) - 5    CASE ... END - 5    a > -5    (-5)
+func (l *Lexer) afterOperand() bool {
+	switch l.lastToken.T {
+	case TokenIdentity, TokenVariable, TokenPlaceholder, TokenRightParenthesis, TokenEnd:
+		return true
+	}
+	return l.lastToken.T.IsLiteral()
+}
+
+// isPlaceholder is true if the next item is a bind parameter in one of the
+// styles enabled by the dialect
+func (l *Lexer) isPlaceholder() bool {
+	styles := l.dialect.Placeholders
+	if styles == 0 || len(l.input)-l.pos < 1 {
+		return false
+	}
+	switch l.input[l.pos] {
+	case '?':
+		return styles&PlaceholderQuestion != 0
+	case '$':
+		return styles&PlaceholderDollar != 0 && len(l.input)-l.pos > 1 && isDigit(rune(l.input[l.pos+1]))
+	case ':':
+		// not the :: of a cast
+		return styles&PlaceholderNamed != 0 && len(l.input)-l.pos > 1 &&
+			isPlaceholderNameRune(rune(l.input[l.pos+1]))
+	}
+	return false
+}
+
+// lexPlaceholder lexes a bind parameter, the token value is its ordinal
+// or name and the Quote is the style's leading ? $ or :
+//
+//    id = ?          V=1 (the count of ? so far in this statement)
+//    id = $2         V=2
+//    id = :user_id   V=user_id
+//
+func lexPlaceholder(l *Lexer) StateFn {
+	mark := l.Next()
+	l.ignore()
+	if mark == '?' {
+		l.placeholders++
+		l.lastQuoteMark = '?'
+		l.EmitValue(TokenPlaceholder, strconv.Itoa(l.placeholders))
+		return nil
+	}
+	for r := l.Next(); isDigit(r) || (mark == ':' && isPlaceholderNameRune(r)); r = l.Next() {
+	}
+	l.backup()
+	l.lastQuoteMark = byte(mark)
+	l.Emit(TokenPlaceholder)
+	return nil
+}
+
+func isPlaceholderNameRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || isDigit(r)
+}
+
+// lexRegexpOperator lexes one of the symbolic regular expression match
+// operators enabled by the dialect, and then the pattern
+//
+//    name ~ '^a'    name !~ '^a'    name ~* '^a'    name !~* '^a'
+//
+func (l *Lexer) lexRegexpOperator() StateFn {
+	// longest spelling first, ~* before ~
+	for n := 3; n > 0; n-- {
+		op := l.PeekX(n)
+		if t, ok := l.dialect.RegexpOperators[op]; ok {
+			l.ConsumeWord(op)
+			l.Emit(t)
+			return LexExpressionOrIdentity
+		}
+	}
+	return l.errorf("unexpected regular expression operator %q", l.PeekX(2))
+}
+
+// lexBetweenAnd lexes the AND separating the low and high bounds of a BETWEEN,
+// then the high bound.  The AND is required here so it is never mistaken
+// for a logical conjunction; it is emitted as TokenLogicAnd as that is
+// what the expression parser expects after the low bound.
+//
+//    year BETWEEN 1 AND 5 AND active = true
+//                   ^
+func lexBetweenAnd(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+
+	if word := strings.ToLower(l.PeekWord()); word != "and" {
+		return l.errorf("expected AND in BETWEEN but got %q", word)
+	}
+	l.ConsumeWord("and")
+	l.Emit(TokenLogicAnd)
+	return LexExpressionOrIdentity
+}
+
+// lexLikeEscape lexes the optional ESCAPE suffix of a LIKE pattern, the
+// escape character is a single character string taken literally so that
+// the common backslash is not mistaken for an escaped quote.
+//
+//    name LIKE 'a\_%' ESCAPE '\'
+//                    ^
+func lexLikeEscape(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+
+	if strings.ToLower(l.PeekWord()) != "escape" {
+		return nil
+	}
+	l.ConsumeWord("escape")
+	l.Emit(TokenEscape)
+	l.SkipWhiteSpaces()
+
+	quote := l.Next()
+	if quote != '\'' && quote != '"' {
+		return l.errorf("expected quoted escape character after ESCAPE but got %q", quote)
+	}
+	l.ignore()
+	if r := l.Next(); r == eof || r == quote || l.Peek() != quote {
+		return l.errorf("expected a single escape character after ESCAPE")
+	}
+	l.lastQuoteMark = byte(quote)
+	l.Emit(TokenValue)
+	l.Next()
+	l.ignore()
+	return nil
+}
+
 // look for either an Identity or Value
 //
 func LexIdentityOrValue(l *Lexer) StateFn {
@@ -1230,7 +1907,7 @@ func LexExpressionParens(l *Lexer) StateFn {
 	firstChar := l.Next()
 	//u.Debugf("LexExpressionParens:  %v", string(firstChar))
 	if firstChar != '(' {
-		u.Errorf("bad expression? %v", string(firstChar))
+		debugf("bad expression? %v", string(firstChar))
 		return l.errorToken("expression must begin with a paren: ( " + l.current())
 	}
 	l.Emit(TokenLeftParenthesis)
@@ -1275,7 +1952,7 @@ func LexParenLeft(l *Lexer) StateFn {
 	r := l.Next()
 	//u.Debugf("LexParenLeft:  %v", string(r))
 	if r != '(' {
-		u.Errorf("bad LexParenLeft? %v", string(r))
+		debugf("bad LexParenLeft? %v", string(r))
 		return l.errorToken("expression must begin with a paren: ( " + l.current())
 	}
 	l.Emit(TokenLeftParenthesis)
@@ -1310,10 +1987,82 @@ func lexExpressionIdentifier(l *Lexer) StateFn {
 	// TODO:  validate identity vs next keyword?, ie ensure it is not a keyword/reserved word
 
 	l.backup() // back up one character
+	if strings.ToLower(l.input[l.start:l.pos]) == "convert" {
+		l.Emit(TokenUdfExpr)
+		return lexConvertArgs
+	}
 	l.Emit(TokenUdfExpr)
 	return LexExpressionParens
 }
 
+// lexConvertArgs lexes the arguments of a CONVERT, the value to convert
+// and its target data type
+//
+//    CONVERT(price, DECIMAL(10,2))
+//    CONVERT(lower(name), CHAR)
+//
+func lexConvertArgs(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+
+	if r := l.Next(); r != '(' {
+		return l.errorf("expected ( after CONVERT but got %q", r)
+	}
+	l.Emit(TokenLeftParenthesis)
+	l.Push("LexParenRight", LexParenRight)
+	l.Push("lexConvertDataType", lexConvertDataType)
+	return LexExpressionOrIdentity
+}
+
+func lexConvertDataType(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+
+	if r := l.Next(); r != ',' {
+		return l.errorf("expected , and data type in CONVERT but got %q", r)
+	}
+	l.Emit(TokenComma)
+	return lexCastDataType
+}
+
+// lexCastDataType lexes the target data type of a CAST or CONVERT as a
+// TokenTypeDef, a data type may be more than one word and have a precision.
+// The type of a :: cast is a single word, as it is not closed by a paren.
+//
+//    CAST(price AS DECIMAL(10,2))
+//    CAST(id AS UNSIGNED INTEGER)
+//    price::numeric(10,2)
+//
+func lexCastDataType(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+
+	var words []string
+	for l.isIdentity() {
+		word := l.PeekWord()
+		if word == "" {
+			break
+		}
+		l.ConsumeWord(word)
+		words = append(words, word)
+		if l.lastToken.T == TokenCastOp {
+			break
+		}
+		l.SkipWhiteSpaces()
+	}
+	if len(words) == 0 {
+		return l.errorf("expected data type but got %q", l.PeekWord())
+	}
+	l.EmitValue(TokenTypeDef, strings.Join(words, " "))
+	if l.Peek() == '(' {
+		l.Next()
+		l.Emit(TokenLeftParenthesis)
+		l.Push("LexParenRight", LexParenRight)
+		return LexListOfArgs
+	}
+	return nil
+}
+
 // LexListOfArgs list of arguments, comma separated list of args which
 // may be a mixture of expressions, identities, values
 //
@@ -1371,15 +2120,38 @@ func LexListOfArgs(l *Lexer) StateFn {
 		//u.Debugf("in LexListOfArgs:  '%s'", peekWord)
 		// First, lets ensure we haven't blown past into keyword?
 		if peekWord == "as" {
+			//  CAST(field AS int)
 			l.Next()
 			l.Next()
 			l.Emit(TokenAs)
-			return LexExpressionOrIdentity
+			return lexCastDataType
 		}
 		if l.isNextKeyword(peekWord) {
 			//u.Warnf("found keyword while looking for arg? %v", string(r))
 			return nil
 		}
+		if peekWord == "case" {
+			l.Push("LexListOfArgs", LexListOfArgs)
+			return LexCase
+		}
+		if l.lastToken.T == TokenLeftParenthesis {
+			//  COUNT(DISTINCT user_id)   SUM(ALL x)
+			// the aggregate modifier, not a func distinct(x) or a column
+			switch peekWord {
+			case "distinct", "all":
+				switch l.peekRunePast(len(peekWord)) {
+				case '(', ')', ',':
+				default:
+					l.ConsumeWord(peekWord)
+					if peekWord == "all" {
+						l.Emit(TokenAll)
+					} else {
+						l.Emit(TokenDistinct)
+					}
+					return LexListOfArgs
+				}
+			}
+		}
 
 		//u.Debugf("LexListOfArgs sending LexExpressionOrIdentity: %v", string(peekWord))
 		l.Push("LexListOfArgs", LexListOfArgs)
@@ -1423,6 +2195,9 @@ func LexIdentifierOfType(forToken TokenType) StateFn {
 func lexIdentifierOfTypeNoWs(l *Lexer, shouldIgnore bool, forToken TokenType) StateFn {
 
 	wasQouted := false
+	qualifiedStar := false
+	escaped := false // has a doubled closing quote  [a]]b]
+	closeQuote := byte(0)
 	// first rune has to be valid unicode letter or @@
 	firstChar := l.Next()
 	//u.Debugf("LexIdentifierOfType:   '%s' ='?%v peek6'%v'", string(firstChar), firstChar == '\'', l.PeekX(6))
@@ -1438,6 +2213,10 @@ func lexIdentifierOfTypeNoWs(l *Lexer, shouldIgnore bool, forToken TokenType) St
 		}
 
 		l.lastQuoteMark = byte(firstChar)
+		closeQuote = byte(firstChar)
+		if firstChar == '[' {
+			closeQuote = ']'
+		}
 		nextChar := l.Next()
 		//u.Debugf("lex firstChar: %s  %s", string(firstChar), string(nextChar))
 		if !unicode.IsLetter(nextChar) {
@@ -1451,8 +2230,11 @@ func lexIdentifierOfTypeNoWs(l *Lexer, shouldIgnore bool, forToken TokenType) St
 	identityForLoop:
 		for {
 			nextChar = l.Next()
-			// TODO:  escaping?
 			switch {
+			case nextChar == rune(closeQuote) && l.PeekX(1) == string(closeQuote):
+				// a doubled closing quote is an escaped quote in the name
+				l.Next()
+				escaped = true
 			case firstChar == '[' && nextChar == ']':
 				if l.PeekX(2) == ".[" {
 					// Identity of form   [schema].[table]
@@ -1460,6 +2242,8 @@ func lexIdentifierOfTypeNoWs(l *Lexer, shouldIgnore bool, forToken TokenType) St
 					l.Next()
 					l.Next()
 				} else {
+					// Qualified wildcard   [table].*
+					qualifiedStar = l.PeekX(2) == ".*"
 					break identityForLoop
 				}
 			case firstChar == '\'' && nextChar == '\'':
@@ -1470,7 +2254,17 @@ func lexIdentifierOfTypeNoWs(l *Lexer, shouldIgnore bool, forToken TokenType) St
 					//u.Warnf("%s", l.RawInput())
 					l.Next()
 					l.Next()
+				} else if p := l.PeekX(2); len(p) == 2 && p[0] == '.' && isIdentifierFirstRune(rune(p[1])) {
+					// Identity of form   `schema`.table.column  the rest
+					// is not quoted so there is no closing quote to skip
+					for r := l.Next(); IsIdentifierRune(r); r = l.Next() {
+					}
+					l.backup()
+					l.Emit(forToken)
+					return nil
 				} else {
+					// Qualified wildcard   `table`.*
+					qualifiedStar = l.PeekX(2) == ".*"
 					break identityForLoop
 				}
 
@@ -1484,7 +2278,7 @@ func lexIdentifierOfTypeNoWs(l *Lexer, shouldIgnore bool, forToken TokenType) St
 		} else if firstChar == nextChar && l.isIdentityQuoteMark(nextChar) {
 			// also valid
 		} else {
-			u.Errorf("unexpected character in identifier?  %v", string(nextChar))
+			debugf("unexpected character in identifier?  %v", string(nextChar))
 			return l.errorToken("unexpected character in identifier:  " + string(nextChar))
 		}
 		wasQouted = true
@@ -1511,7 +2305,8 @@ func lexIdentifierOfTypeNoWs(l *Lexer, shouldIgnore bool, forToken TokenType) St
 			return l.errorToken("identifier must begin with a letter " + string(l.input[l.start:l.pos]))
 		}
 
-		if r != '*' {
+		// Qualified wildcard   table.*
+		if r != '*' || lastRune != '.' {
 			l.backup()
 		}
 
@@ -1526,8 +2321,24 @@ func lexIdentifierOfTypeNoWs(l *Lexer, shouldIgnore bool, forToken TokenType) St
 
 	}
 
+	name := l.input[l.start:l.pos]
+	if escaped {
+		q := string(closeQuote)
+		name = strings.Replace(name, q+q, q, -1)
+	}
+
+	if qualifiedStar {
+		// emit the un-quoted name with the wildcard, then consume
+		// the closing quote and the .*
+		l.Next()
+		l.Next()
+		l.Next()
+		l.EmitValue(forToken, name+".*")
+		return nil
+	}
+
 	//u.Debugf("about to emit: %v", forToken)
-	l.Emit(forToken)
+	l.EmitValue(forToken, name)
 	if wasQouted {
 		// need to skip last character bc it was quoted
 		l.Next()
@@ -1576,13 +2387,42 @@ func LexDataType(forToken TokenType) StateFn {
 	}
 }
 
+// endOfStatement emits the EOS of an already consumed semicolon, and if
+// there is more input starts over with the next statement, so a script of
+// many statements is lexed as one stream
+//
+//    SELECT a FROM t1; SELECT b FROM t2;
+//
+func (l *Lexer) endOfStatement() StateFn {
+	l.Emit(TokenEOS)
+	// statement is over, anything still on the stack belongs to it
+	l.stack = l.stack[:0]
+	l.caseStack = l.caseStack[:0]
+	l.placeholders = 0
+	if l.IsEnd() {
+		return nil
+	}
+	return LexDialectForStatement
+}
+
 // Look for end of statement defined by either a semicolon or end of file
 func LexEndOfStatement(l *Lexer) StateFn {
 	l.SkipWhiteSpaces()
+	if l.StrictMode {
+		if c := l.earlierClause(strings.ToLower(l.PeekWord())); c != nil {
+			if l.curClause.fullWord == "" {
+				return l.errorf("%s is out of order", strings.ToUpper(c.fullWord))
+			}
+			return l.errorf("%s must come before %s", strings.ToUpper(c.fullWord), strings.ToUpper(l.curClause.fullWord))
+		}
+	}
 	r := l.Next()
 	if r == ';' {
-		l.Emit(TokenEOS)
-		return nil
+		return l.endOfStatement()
+	}
+	if l.StrictMode && r != eof {
+		l.backup()
+		return l.errorf("unexpected %q", r)
 	}
 	l.SkipWhiteSpaces()
 	if l.IsEnd() {
@@ -1606,6 +2446,11 @@ func LexSelectClause(l *Lexer) StateFn {
 	if l.IsEnd() {
 		return nil
 	}
+	if strings.HasPrefix(l.input[l.pos:], "/*") {
+		//  SELECT /*+ shard(us-east) */ * FROM t
+		l.Push("LexSelectClause", LexSelectClause)
+		return LexMultilineComment
+	}
 	if l.Peek() == ')' {
 		return nil
 	}
@@ -1669,6 +2514,25 @@ func LexSelectClause(l *Lexer) StateFn {
 	return LexSelectList
 }
 
+// LexSelectInto lexes the target of a SELECT ... INTO, either OUTFILE and
+// its quoted path, or the table or @variable the results go into
+//
+//    SELECT * FROM t INTO OUTFILE '/tmp/x.csv'
+//    SELECT a INTO @var FROM t
+//
+func LexSelectInto(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	if word := l.PeekWord(); strings.ToLower(word) == "outfile" {
+		l.ConsumeWord(word)
+		l.Emit(TokenOutfile)
+		return LexValue
+	}
+	if l.Peek() == '@' {
+		return LexIdentifierOfType(TokenVariable)
+	}
+	return LexIdentifierOfType(TokenTable)
+}
+
 // Handle start of insert, Upsert statements
 //
 func LexUpsertClause(l *Lexer) StateFn {
@@ -1703,6 +2567,124 @@ func LexUpsertClause(l *Lexer) StateFn {
 	return nil
 }
 
+// isSubQueryNext determines if the upcoming input is a parenthesized
+// SELECT statement, without consuming it.
+func (l *Lexer) isSubQueryNext() bool {
+	i := l.pos
+	for ; i < len(l.input) && isWhiteSpace(rune(l.input[i])); i++ {
+	}
+	if i >= len(l.input) || l.input[i] != '(' {
+		return false
+	}
+	for i++; i < len(l.input) && isWhiteSpace(rune(l.input[i])); i++ {
+	}
+	if i+len("select") > len(l.input) || !strings.EqualFold(l.input[i:i+len("select")], "select") {
+		return false
+	}
+	i += len("select")
+	return i == len(l.input) || !isIdentCh(rune(l.input[i]))
+}
+
+// isSubQueryStart determines if the input, after an already consumed left
+// paren, is a SELECT statement, without consuming it.
+func (l *Lexer) isSubQueryStart() bool {
+	i := l.pos
+	for ; i < len(l.input) && isWhiteSpace(rune(l.input[i])); i++ {
+	}
+	if i+len("select") > len(l.input) || !strings.EqualFold(l.input[i:i+len("select")], "select") {
+		return false
+	}
+	i += len("select")
+	return i == len(l.input) || !isIdentCh(rune(l.input[i]))
+}
+
+// matchingParen finds the position of the right paren that closes an
+// already consumed left paren, skipping over quoted values. Returns -1
+// if there is none.
+func (l *Lexer) matchingParen() int {
+	depth := 1
+	var quote byte
+	for i := l.pos; i < len(l.input); i++ {
+		c := l.input[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// LexSubStatement lexes a parenthesized statement, ie the sub-query in
+//
+//    SELECT t.a FROM (SELECT a FROM users) AS t
+//
+// using a new lexer for the statement, which shares the MaxDepth of this
+// one so nested sub-queries are limited too.  The left paren has already
+// been consumed, the right paren is emitted once the statement ends.
+func LexSubStatement(l *Lexer) StateFn {
+	end := l.matchingParen()
+	if end < 0 {
+		return l.errorf("expected ) to end sub-query")
+	}
+	// the sub-query lexer gets what is left of the depth budget, or nested
+	// sub-queries would each start over with an empty stack
+	depth := l.MaxDepth - len(l.stack) - 1
+	if depth <= 0 {
+		return l.errorf("expression is nested too deeply, max depth is %d", l.MaxDepth)
+	}
+	// lex the same input so positions are retained
+	sub := NewLexer(l.input[:end], l.dialect)
+	sub.StrictMode = l.StrictMode
+	sub.CommentTrivia = l.CommentTrivia
+	sub.MaxDepth = depth
+	sub.placeholders = l.placeholders
+	sub.pos, sub.start = l.pos, l.pos
+	sub.line, sub.linepos = l.line, l.linepos
+
+	var lexSub StateFn
+	lexSub = func(l *Lexer) StateFn {
+		tok := sub.NextToken()
+		switch tok.T {
+		case TokenEOF:
+			l.pos, l.start = end, end
+			l.line, l.linepos = sub.line, sub.linepos
+			l.placeholders = sub.placeholders
+			l.mergeTrivia(sub)
+			l.Next()
+			l.Emit(TokenRightParenthesis)
+			return nil
+		case TokenError:
+			l.err = sub.err
+			l.stats.err = true
+			l.stack = l.stack[:0]
+		}
+		l.lastToken = tok
+		l.tokens = append(l.tokens, tok)
+		l.stats.count(tok.T)
+		if depth := len(l.stack) + sub.stats.maxDepth; depth > l.stats.maxDepth {
+			l.stats.maxDepth = depth
+		}
+		if tok.T == TokenError {
+			return nil
+		}
+		return lexSub
+	}
+	return lexSub
+}
+
 // Handle recursive subqueries
 //
 func LexSubQuery(l *Lexer) StateFn {
@@ -1739,7 +2721,41 @@ func LexSubQuery(l *Lexer) StateFn {
 		l.Push("LexSubQuery", LexSubQuery)
 		l.Push("LexConditionalClause", LexConditionalClause)
 		return LexTableReferences
+	case "group":
+		if l.tryMatch(TokenGroupBy.String()) {
+			l.Emit(TokenGroupBy)
+			l.Push("LexSubQuery", LexSubQuery)
+			return LexGroupByColumns
+		}
+	case "having":
+		l.ConsumeWord(word)
+		l.Emit(TokenHaving)
+		l.Push("LexSubQuery", LexSubQuery)
+		return LexConditionalClause
+	case "order":
+		if l.tryMatch(TokenOrderBy.String()) {
+			l.Emit(TokenOrderBy)
+			l.Push("LexSubQuery", LexSubQuery)
+			return LexOrderByColumn
+		}
+	case "limit":
+		l.ConsumeWord(word)
+		l.Emit(TokenLimit)
+		l.Push("LexSubQuery", LexSubQuery)
+		return LexLimit
 	default:
+		if op, ok := l.dialect.SetOperators[word]; ok {
+			// joins the selects of this sub-query, the statement's own
+			// set operator clause would start the statement over
+			l.ConsumeWord(word)
+			l.Emit(op)
+			l.SkipWhiteSpaces()
+			if strings.ToLower(l.PeekWord()) == "all" {
+				l.ConsumeWord("all")
+				l.Emit(TokenAll)
+			}
+			return LexSubQuery
+		}
 	}
 
 	l.Push("LexSubQuery", LexSubQuery)
@@ -1773,8 +2789,7 @@ func LexPreparedStatement(l *Lexer) StateFn {
 		r := l.Peek()
 		if r == ';' {
 			l.Next()
-			l.Emit(TokenEOS)
-			return nil
+			return l.endOfStatement()
 		}
 	}
 
@@ -1798,20 +2813,79 @@ func LexSelectList(l *Lexer) StateFn {
 		return nil
 	}
 	word := strings.ToLower(l.PeekWord())
-	//u.Debugf("LexSelectList looking for operator:  word=%q", word)
+	//u.Debugf("LexSelectList looking for operator:  word=%q", word)
+	switch word {
+	case "as":
+		l.ConsumeWord(word)
+		l.Emit(TokenAs)
+		l.Push("LexSelectList", LexSelectList)
+		return LexIdentifier
+	case "if":
+		l.skipX(2)
+		l.Emit(TokenIf)
+		l.Push("LexSelectList", LexSelectList)
+		return LexExpression
+	}
+	if l.Peek() == '(' {
+		// a grouped expression may be followed by more of the expression
+		//   (a + b) * 2 AS c
+		l.Push("LexSelectList", LexSelectList)
+	}
+	return LexExpression
+}
+
+// lexWindowSpec lexes the parenthesized window specification of a window
+// function, after the OVER has been consumed.  Both parts are optional.
+//
+//    rank() OVER ( [PARTITION BY <expr> [, <expr>]*] [ORDER BY <expr> [ASC|DESC] [, ...]] )
+//
+func lexWindowSpec(l *Lexer) StateFn {
+
+	l.SkipWhiteSpaces()
+	if l.lastToken.T == TokenOver {
+		l.Next()
+		l.Emit(TokenLeftParenthesis)
+		return lexWindowSpec
+	}
+	if l.IsEnd() {
+		return l.errorf("expected ) to end window specification but got EOF")
+	}
+
+	switch l.Peek() {
+	case ')':
+		l.Next()
+		l.Emit(TokenRightParenthesis)
+		return nil
+	case ',':
+		l.Next()
+		l.Emit(TokenComma)
+		l.Push("lexWindowSpec", lexWindowSpec)
+		return LexExpressionOrIdentity
+	}
+
+	word := strings.ToLower(l.PeekWord())
 	switch word {
-	case "as":
+	case "partition", "order":
+		tok := TokenPartitionBy
+		if word == "order" {
+			tok = TokenOrderBy
+		}
+		if !l.tryMatch(tok.String()) {
+			return l.errorf("expected %s in window specification", strings.ToUpper(tok.String()))
+		}
+		l.Emit(tok)
+		l.Push("lexWindowSpec", lexWindowSpec)
+		return LexExpressionOrIdentity
+	case "asc":
 		l.ConsumeWord(word)
-		l.Emit(TokenAs)
-		l.Push("LexSelectList", LexSelectList)
-		return LexIdentifier
-	case "if":
-		l.skipX(2)
-		l.Emit(TokenIf)
-		l.Push("LexSelectList", LexSelectList)
-		return LexExpression
+		l.Emit(TokenAsc)
+		return lexWindowSpec
+	case "desc":
+		l.ConsumeWord(word)
+		l.Emit(TokenDesc)
+		return lexWindowSpec
 	}
-	return LexExpression
+	return l.errorf("expected PARTITION BY, ORDER BY or ) in window specification but got %q", word)
 }
 
 // Handle Source References ie [From table], [SubSelects], Joins
@@ -1821,7 +2895,7 @@ func LexSelectList(l *Lexer) StateFn {
 //    <sources>      := <source> [, <join_clause> <source>]*
 //    <source>       := ( <table_source> | <subselect> ) [AS <identifier>]
 //    <table_source> := <identifier>
-//    <join_clause>  := (INNER | LEFT | OUTER)? JOIN [ON <conditional_clause>]
+//    <join_clause>  := [(LEFT | RIGHT | FULL)] [(INNER | OUTER | CROSS)] JOIN [ON <conditional_clause>]
 //    <subselect>    := '(' <select_stmt> ')'
 //
 func LexTableReferenceFirst(l *Lexer) StateFn {
@@ -1841,13 +2915,16 @@ func LexTableReferenceFirst(l *Lexer) StateFn {
 	switch r {
 	case ';':
 		l.Next()
-		l.Emit(TokenEOS)
-		return nil
+		return l.endOfStatement()
 	case '(':
 		l.Next()
 		l.Emit(TokenLeftParenthesis)
 		// subquery
 		l.Push("LexTableReferenceFirst", LexTableReferenceFirst)
+		if l.isSubQueryStart() {
+			//  FROM (SELECT ...) AS t
+			return LexSubStatement
+		}
 		//l.Push("LexParenRight", LexParenRight)
 		//l.clauseState() = LexSelectClause
 		//return LexSelectClause
@@ -1892,12 +2969,7 @@ func LexTableReferenceFirst(l *Lexer) StateFn {
 	}
 	//u.LogTracef(u.WARN, "hmmmmmmm")
 	//u.Debugf("LexTableReferenceFirst = '%v'", string(r))
-	// ensure we don't get into a recursive death spiral here?
-	if len(l.stack) < 100 {
-		l.Push("LexTableReferenceFirst", LexTableReferenceFirst)
-	} else {
-		u.Errorf("Gracefully refusing to add more LexTableReferenceFirst: ")
-	}
+	l.Push("LexTableReferenceFirst", LexTableReferenceFirst)
 
 	// Since we did Not find anything, we are going to go for a Expression or Identity
 	return LexExpressionOrIdentity
@@ -1910,7 +2982,7 @@ func LexTableReferenceFirst(l *Lexer) StateFn {
 //    <sources>      := <source> [, <join_clause> <source>]*
 //    <source>       := ( <table_source> | <subselect> ) [AS <identifier>]
 //    <table_source> := <identifier>
-//    <join_clause>  := (INNER | LEFT | OUTER)? JOIN [ON <conditional_clause>]
+//    <join_clause>  := [(LEFT | RIGHT | FULL)] [(INNER | OUTER | CROSS)] JOIN [ON <conditional_clause>]
 //    <subselect>    := '(' <select_stmt> ')'
 //
 func LexTableReferences(l *Lexer) StateFn {
@@ -1930,8 +3002,7 @@ func LexTableReferences(l *Lexer) StateFn {
 	switch r {
 	case ';':
 		l.Next()
-		l.Emit(TokenEOS)
-		return nil
+		return l.endOfStatement()
 	case '(':
 		l.Next()
 		l.Emit(TokenLeftParenthesis)
@@ -1981,6 +3052,14 @@ func LexTableReferences(l *Lexer) StateFn {
 		l.ConsumeWord(word)
 		l.Emit(TokenRight)
 		return LexTableReferences
+	case "full":
+		l.ConsumeWord(word)
+		l.Emit(TokenFull)
+		return LexTableReferences
+	case "cross":
+		l.ConsumeWord(word)
+		l.Emit(TokenCross)
+		return LexTableReferences
 	case "join":
 		l.ConsumeWord(word)
 		l.Emit(TokenJoin)
@@ -2017,12 +3096,7 @@ func LexTableReferences(l *Lexer) StateFn {
 	}
 	//u.LogTracef(u.WARN, "hmmmmmmm")
 	//u.Debugf("LexTableReferences = '%v'", string(r))
-	// ensure we don't get into a recursive death spiral here?
-	if len(l.stack) < 100 {
-		l.Push("LexTableReferences", LexTableReferences)
-	} else {
-		u.Errorf("Gracefully refusing to add more LexTableReferences: ")
-	}
+	l.Push("LexTableReferences", LexTableReferences)
 
 	// Since we did Not find anything, we are going to go for a Expression or Identity
 	return LexExpressionOrIdentity
@@ -2035,7 +3109,7 @@ func LexTableReferences(l *Lexer) StateFn {
 //    <sources>      := <source> [, <join_clause> <source>]*
 //    <source>       := ( <table_source> | <subselect> ) [AS <identifier>]
 //    <table_source> := <identifier>
-//    <join_clause>  := (INNER | LEFT | OUTER)? JOIN [ON <conditional_clause>]
+//    <join_clause>  := [(LEFT | RIGHT | FULL)] [(INNER | OUTER | CROSS)] JOIN [ON <conditional_clause>]
 //    <subselect>    := '(' <select_stmt> ')'
 //
 func LexJoinEntry(l *Lexer) StateFn {
@@ -2053,8 +3127,7 @@ func LexJoinEntry(l *Lexer) StateFn {
 	switch r {
 	case ';':
 		l.Next()
-		l.Emit(TokenEOS)
-		return nil
+		return l.endOfStatement()
 	case '(':
 		l.Next()
 		l.Emit(TokenLeftParenthesis)
@@ -2091,10 +3164,23 @@ func LexJoinEntry(l *Lexer) StateFn {
 		l.ConsumeWord(word)
 		l.Emit(TokenRight)
 		return LexJoinEntry
+	case "full":
+		l.ConsumeWord(word)
+		l.Emit(TokenFull)
+		return LexJoinEntry
+	case "cross":
+		l.ConsumeWord(word)
+		l.Emit(TokenCross)
+		return LexJoinEntry
 	case "join":
 		l.ConsumeWord(word)
 		l.Emit(TokenJoin)
 		return LexJoinEntry
+	case "as":
+		l.ConsumeWord(word)
+		l.Emit(TokenAs)
+		l.Push("LexJoinEntry", LexJoinEntry)
+		return LexIdentifier
 	// case "in":
 	// 	return nil
 
@@ -2103,24 +3189,21 @@ func LexJoinEntry(l *Lexer) StateFn {
 			return nil
 		}
 		if l.isIdentity() {
-			//u.Debugf("expression or identity?")
-			return LexExpressionOrIdentity
+			//  JOIN <table> [AS] <alias>
+			l.Push("LexJoinEntry", LexJoinEntry)
+			return LexIdentifier
 		}
 	}
 	//u.LogTracef(u.WARN, "hmmmmmmm")
 	//u.Debugf("LexJoinEntry = '%v'", string(r))
-	// ensure we don't get into a recursive death spiral here?
-	if len(l.stack) < 100 {
-		l.Push("LexJoinEntry", LexJoinEntry)
-	} else {
-		u.Errorf("Gracefully refusing to add more LexJoinEntry: ")
-	}
+	l.Push("LexJoinEntry", LexJoinEntry)
 
 	// Since we did Not find anything, we are going to go for a Expression or Identity
 	return LexExpressionOrIdentity
 }
 
-// LexColumnNames Handle list of column names on insert/update statements
+// LexColumnNames Handle a parenthesized list of column names, such as on
+// insert statements or the columns of an index.  The names may be quoted.
 //
 //     <insert_into> <col_names> VALUES <col_value_list>
 //
@@ -2130,23 +3213,43 @@ func LexColumnNames(l *Lexer) StateFn {
 	l.SkipWhiteSpaces()
 	r := l.Peek()
 	//u.Debugf("LexColumnNames lr=%s  word=%q", string(r), l.PeekWord())
-	switch r {
-	case ',':
-		l.Next()
-		l.Emit(TokenComma)
-		return LexColumnNames
-	case ')':
-		l.Next()
-		l.Emit(TokenRightParenthesis)
-		return nil
-	case '(':
-		l.Next()
-		l.Emit(TokenLeftParenthesis)
-		return LexColumnNames
-	default:
+	switch l.lastToken.T {
+	case TokenLeftParenthesis, TokenComma:
+		// a column name, or the end of an empty list
+		if r == ')' && l.lastToken.T == TokenLeftParenthesis {
+			l.Next()
+			l.Emit(TokenRightParenthesis)
+			return nil
+		}
+		if r == eof {
+			return l.errorf("expected column name but got EOF")
+		} else if !l.isIdentity() {
+			return l.errorf("expected column name but got %q", r)
+		}
 		l.Push("LexColumnNames", LexColumnNames)
 		return LexIdentifier
+	case TokenIdentity:
+		switch r {
+		case ',':
+			l.Next()
+			l.Emit(TokenComma)
+			return LexColumnNames
+		case ')':
+			l.Next()
+			l.Emit(TokenRightParenthesis)
+			return nil
+		}
+		if r == eof {
+			return l.errorf("expected , or ) in column names but got EOF")
+		}
+		return l.errorf("expected , or ) in column names but got %q", l.PeekWord())
+	}
+	if r != '(' {
+		return l.errorf("expected ( to start column names but got %q", r)
 	}
+	l.Next()
+	l.Emit(TokenLeftParenthesis)
+	return LexColumnNames
 }
 
 // Handle repeating Insert/Upsert/Update statements
@@ -2193,6 +3296,11 @@ func LexTableColumns(l *Lexer) StateFn {
 		l.ConsumeWord(word)
 		l.Emit(TokenSet)
 		return LexColumns
+	case "on":
+		if onDuplicateKeyMatch(nil, word, l) {
+			return nil
+		}
+		return LexColumns
 	default:
 		switch l.lastToken.T {
 		case TokenLeftParenthesis:
@@ -2211,7 +3319,6 @@ func LexTableColumns(l *Lexer) StateFn {
 func LexValueColumns(l *Lexer) StateFn {
 	l.SkipWhiteSpaces()
 	r := l.Peek()
-	//u.Debugf("LexValueColumns  r= %v'", string(r))
 
 	switch r {
 	case eof:
@@ -2268,6 +3375,10 @@ func LexConditionalClause(l *Lexer) StateFn {
 		l.Next()
 		l.Emit(TokenLeftParenthesis)
 		l.Push("LexConditionalClause", LexConditionalClause)
+		if l.isSubQueryStart() {
+			//  WHERE (SELECT max(b) FROM u) > 1
+			return LexSubStatement
+		}
 		l.Push("LexConditionalClause", LexConditionalClause)
 		l.Push("LexParenRight", LexParenRight)
 		return LexConditionalClause
@@ -2352,7 +3463,9 @@ func LexExpression(l *Lexer) StateFn {
 		return LexComment
 	}
 
-	debugf("LexExpression stack=%d  r='%v' word=%q", len(l.stack), string(l.Peek()), l.PeekX(20))
+	if Trace {
+		debugf("LexExpression stack=%d  r='%v' word=%q", len(l.stack), string(l.Peek()), l.PeekX(20))
+	}
 
 	r := l.Next()
 	// Cover the logic and grouping
@@ -2373,9 +3486,26 @@ func LexExpression(l *Lexer) StateFn {
 			l.Push("LexExpression", l.clauseState())
 			return LexIdentifier
 		}
-		u.Warnf("un-handled? ")
+		debugf("un-handled? ")
+	case ':':
+		if l.dialect.CastOperator && l.Peek() == ':' {
+			//  price::text
+			l.Next()
+			l.Emit(TokenCastOp)
+			l.Push("LexExpression", l.clauseState())
+			return lexCastDataType
+		}
+		if l.Peek() == ':' {
+			return l.errorf("unexpected '::', the cast operator is not enabled in this dialect")
+		}
 	case '(': // this is a logical Grouping/Ordering and must be a single
 		// logically valid expression
+		if l.isSubQueryStart() {
+			//  x = 1 AND (SELECT max(b) FROM u) > 1
+			l.Emit(TokenLeftParenthesis)
+			l.Push("LexExpression", l.clauseState())
+			return LexSubStatement
+		}
 		l.Push("LexParenRight", LexParenRight)
 		l.Emit(TokenLeftParenthesis)
 		l.Push("LexExpression", l.clauseState())
@@ -2384,7 +3514,7 @@ func LexExpression(l *Lexer) StateFn {
 		//l.Emit(TokenRightParenthesis)
 		l.backup() // don't consume )
 		return nil
-	case '!', '=', '>', '<', ',', ';', '-', '*', '+', '%', '&', '/', '|':
+	case '!', '=', '>', '<', ',', ';', '-', '*', '+', '%', '&', '/', '|', '~', '^':
 		foundLogical := false
 		foundOperator := false
 		switch r {
@@ -2394,6 +3524,11 @@ func LexExpression(l *Lexer) StateFn {
 				l.backup()
 				l.Push("LexExpression", LexExpression)
 				return LexInlineComment
+			} else if isDigit(p) && !l.afterOperand() {
+				// a negative number literal, not the minus operator
+				l.backup()
+				l.Push("LexExpression", l.clauseState())
+				return LexNumber
 			} else {
 				l.Emit(TokenMinus)
 				return l.clauseState()
@@ -2404,17 +3539,26 @@ func LexExpression(l *Lexer) StateFn {
 		case ',':
 			l.Emit(TokenComma)
 			return l.clauseState()
-		case '!': //  !=
+		case '!': //  !=   !~
 			if r2 := l.Peek(); r2 == '=' {
 				l.Next()
 				l.Emit(TokenNE)
 				foundLogical = true
+			} else if r2 == '~' {
+				l.backup()
+				return l.lexRegexpOperator()
 			} else {
 				l.Emit(TokenNegate)
 				return nil
 			}
+		case '~':
+			l.backup()
+			return l.lexRegexpOperator()
 		case '=':
 			if r2 := l.Peek(); r2 == '=' {
+				if l.dialect.StrictEquality {
+					return l.errorf("unexpected '==', use = for equality")
+				}
 				l.Next()
 				l.Emit(TokenEqualEqual)
 				foundOperator = true
@@ -2427,17 +3571,31 @@ func LexExpression(l *Lexer) StateFn {
 				l.Next()
 				l.Emit(TokenOr)
 				foundOperator = true
+			} else if !l.dialect.DisableBitwise {
+				l.Emit(TokenBitOr)
+				foundOperator = true
 			}
 		case '&':
 			if r2 := l.Peek(); r2 == '&' {
 				l.Next()
 				l.Emit(TokenAnd)
 				foundOperator = true
+			} else if !l.dialect.DisableBitwise {
+				l.Emit(TokenBitAnd)
+				foundOperator = true
+			}
+		case '^':
+			if !l.dialect.DisableBitwise {
+				l.Emit(TokenBitXor)
+				foundOperator = true
 			}
 		case '>':
 			if r2 := l.Peek(); r2 == '=' {
 				l.Next()
 				l.Emit(TokenGE)
+			} else if r2 == '>' && !l.dialect.DisableBitwise {
+				l.Next()
+				l.Emit(TokenRightShift)
 			} else {
 				l.Emit(TokenGT)
 			}
@@ -2445,12 +3603,21 @@ func LexExpression(l *Lexer) StateFn {
 		case '<':
 			if r2 := l.Peek(); r2 == '=' {
 				l.Next()
-				l.Emit(TokenLE)
+				if l.Peek() == '>' { //   <=>
+					l.Next()
+					l.Emit(TokenNullSafeEqual)
+				} else {
+					l.Emit(TokenLE)
+				}
 				foundLogical = true
 			} else if r2 == '>' { //   <>
 				l.Next()
 				l.Emit(TokenNE)
 				foundOperator = true
+			} else if r2 == '<' && !l.dialect.DisableBitwise {
+				l.Next()
+				l.Emit(TokenLeftShift)
+				foundOperator = true
 			} else {
 				l.Emit(TokenLT)
 				foundOperator = true
@@ -2489,9 +3656,30 @@ func LexExpression(l *Lexer) StateFn {
 	l.backup()
 	word := strings.ToLower(l.PeekWord())
 	// u.Debugf("LexExpression operator:  word=%q  kw?%v", word, l.isNextKeyword(word))
+	if op, ok := l.dialect.RegexpOperators[word]; ok {
+		//  name REGEXP '^a.*'
+		l.ConsumeWord(word)
+		l.Emit(op)
+		return LexExpressionOrIdentity
+	}
+	if word == "ilike" && l.dialect.ILike {
+		//  name ILIKE 'bob%'
+		l.ConsumeWord(word)
+		l.Emit(TokenILike)
+		l.Push("lexLikeEscape", lexLikeEscape)
+		return LexExpressionOrIdentity
+	}
 	switch word {
 	case "as":
 		return nil
+	case "over":
+		if l.lastToken.T == TokenRightParenthesis && l.peekRunePast(len(word)) == '(' {
+			//  rank() OVER (PARTITION BY team ORDER BY score DESC)
+			l.ConsumeWord(word)
+			l.Emit(TokenOver)
+			l.Push("LexExpression-clauseState", l.clauseState())
+			return lexWindowSpec
+		}
 	case "in", "intersects", "like", "between", "contains": // what is complete list here?
 		switch word {
 		case "in":
@@ -2502,9 +3690,9 @@ func LexExpression(l *Lexer) StateFn {
 				l.ConsumeWord("(")
 				l.Emit(TokenLeftParenthesis)
 				l.SkipWhiteSpaces()
-				word = strings.ToLower(l.PeekWord())
-				if word == "select" {
-					return nil
+				if l.isSubQueryStart() {
+					//  x IN (SELECT b FROM u)
+					return LexSubStatement
 				}
 				l.Push("LexParenRight", LexParenRight)
 				return LexListOfArgs
@@ -2522,6 +3710,7 @@ func LexExpression(l *Lexer) StateFn {
 		case "like":
 			l.ConsumeWord(word)
 			l.Emit(TokenLike)
+			l.Push("lexLikeEscape", lexLikeEscape)
 			return LexExpressionOrIdentity
 		case "contains":
 			l.ConsumeWord(word)
@@ -2536,6 +3725,7 @@ func LexExpression(l *Lexer) StateFn {
 			l.ConsumeWord(word)
 			l.Emit(TokenBetween)
 			l.Push("LexExpression", LexExpression)
+			l.Push("lexBetweenAnd", lexBetweenAnd)
 			l.Push("LexExpressionOrIdentity", LexExpressionOrIdentity)
 			return nil
 		}
@@ -2543,9 +3733,21 @@ func LexExpression(l *Lexer) StateFn {
 		l.ConsumeWord(word)
 		l.Emit(TokenInclude)
 		return LexIdentifier
+	case "case":
+		l.Push("LexExpression-clauseStatex", l.clauseState())
+		return LexCase
 	case "exists":
 		l.ConsumeWord(word)
 		r = l.Peek()
+		if l.isSubQueryNext() {
+			//  EXISTS (SELECT 1 FROM child WHERE child.pid = parent.id)
+			l.Emit(TokenExists)
+			l.SkipWhiteSpaces()
+			l.ConsumeWord("(")
+			l.Emit(TokenLeftParenthesis)
+			l.Push("LexExpression", l.clauseState())
+			return LexSubStatement
+		}
 		if r == '(' {
 			l.Emit(TokenUdfExpr)
 			l.ConsumeWord("(")
@@ -2556,6 +3758,47 @@ func LexExpression(l *Lexer) StateFn {
 		}
 		l.Emit(TokenExists)
 		return LexExpression
+	case "date", "time", "timestamp":
+		if l.isTypedLiteral() {
+			//  created > DATE '2017-01-01'
+			l.Push("LexExpression", l.clauseState())
+			return lexTypedLiteral
+		}
+	case "interval":
+		if l.isInterval() {
+			//  ts > now() - INTERVAL '7' DAY
+			l.Push("LexExpression", l.clauseState())
+			return lexInterval
+		}
+	case "any", "all", "some":
+		if l.lastToken.T.IsComparison() && l.peekRunePast(len(word)) == '(' {
+			//  x > ALL (SELECT b FROM u)
+			//  x = ANY (1, 2, 3)
+			l.ConsumeWord(word)
+			l.Emit(quantifiers[word])
+			l.SkipWhiteSpaces()
+			subQuery := l.isSubQueryNext()
+			l.ConsumeWord("(")
+			l.Emit(TokenLeftParenthesis)
+			if subQuery {
+				l.Push("LexExpression", l.clauseState())
+				return LexSubStatement
+			}
+			l.Push("LexParenRight", LexParenRight)
+			return LexListOfArgs
+		}
+	case "tag":
+		if l.dialect.Tags && l.peekRunePast(len(word)) == '(' {
+			//  GROUP BY tag(host)
+			//  WHERE tag(region) = 'us-east'
+			l.ConsumeWord(word)
+			l.Emit(TokenTag)
+			l.SkipWhiteSpaces()
+			l.ConsumeWord("(")
+			l.Emit(TokenLeftParenthesis)
+			l.Push("LexParenRight", LexParenRight)
+			return LexIdentifier
+		}
 	case "is":
 		l.ConsumeWord(word)
 		l.Emit(TokenIs)
@@ -2645,13 +3888,190 @@ func LexExpression(l *Lexer) StateFn {
 			return nil
 		}
 	}
-	// ensure we don't get into a recursive death spiral here?
-	if len(l.stack) < 1000 {
-		l.Push("LexExpression-clauseStatex", l.clauseState())
+	l.Push("LexExpression-clauseStatex", l.clauseState())
+	return LexExpressionOrIdentity
+}
+
+// quantifiers are the keywords of a quantified comparison and their tokens
+var quantifiers = map[string]TokenType{
+	"any":  TokenAny,
+	"all":  TokenAll,
+	"some": TokenSome,
+}
+
+// typedLiterals are the ANSI typed literal keywords and their tokens
+var typedLiterals = map[string]TokenType{
+	"date":      TokenDate,
+	"time":      TokenTime,
+	"timestamp": TokenTimestamp,
+}
+
+// isTypedLiteral is true if the next item is an ANSI typed literal
+func (l *Lexer) isTypedLiteral() bool {
+	word := strings.ToLower(l.PeekWord())
+	_, ok := typedLiterals[word]
+	return ok && l.peekRunePast(len(word)) == '\''
+}
+
+// lexTypedLiteral lexes an ANSI typed literal, the keyword and its quoted
+// value are emitted as one token of that type, whose value is the text
+// between the quotes with any escaped quotes unescaped
+//
+//    DATE '2017-01-01'                  -> TokenDate "2017-01-01"
+//    TIMESTAMP '2017-01-01 10:00:00'    -> TokenTimestamp "2017-01-01 10:00:00"
+//
+func lexTypedLiteral(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	word := strings.ToLower(l.PeekWord())
+	l.ConsumeWord(word)
+	l.SkipWhiteSpaces()
+	l.Next() // opening quote
+	l.ignore()
+	escaped, ok := l.scanQuotedValue('\'')
+	if !ok {
+		return l.errorf("reached end without finding end of %s literal", strings.ToUpper(word))
+	}
+	// the value without the closing quote, then consume it
+	l.pos--
+	if escaped {
+		val := Token{T: TokenValueEscaped, V: l.input[l.start:l.pos], Quote: '\''}
+		l.EmitValue(typedLiterals[word], val.UnEscapedValue())
 	} else {
-		u.LogThrottle(u.WARN, 10, "Gracefully refusing to add more LexExpression: %s", l.input)
+		l.Emit(typedLiterals[word])
 	}
-	return LexExpressionOrIdentity
+	l.pos++
+	l.ignore()
+	return nil
+}
+
+// intervalUnits are the units of an INTERVAL quantity
+var intervalUnits = map[string]bool{
+	"microsecond": true, "microseconds": true,
+	"millisecond": true, "milliseconds": true,
+	"second": true, "seconds": true,
+	"minute": true, "minutes": true,
+	"hour": true, "hours": true,
+	"day": true, "days": true,
+	"week": true, "weeks": true,
+	"month": true, "months": true,
+	"quarter": true, "quarters": true,
+	"year": true, "years": true,
+}
+
+// isInterval is true if the next item is an INTERVAL literal
+func (l *Lexer) isInterval() bool {
+	word := l.PeekWord()
+	if !strings.EqualFold(word, "interval") {
+		return false
+	}
+	r := l.peekRunePast(len(word))
+	return r == '\'' || isDigit(r)
+}
+
+// lexInterval lexes an INTERVAL literal, its quantity either quoted or a
+// bare number, and the unit as a TokenIntervalUnit.  Units not known are
+// left to be lexed as identities.
+//
+//    INTERVAL '7' DAY   -> TokenInterval, TokenValue "7", TokenIntervalUnit "DAY"
+//    INTERVAL 7 DAY     -> TokenInterval, TokenInteger "7", TokenIntervalUnit "DAY"
+//
+func lexInterval(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	l.ConsumeWord("interval")
+	l.Emit(TokenInterval)
+	l.SkipWhiteSpaces()
+	if l.Peek() == '\'' {
+		l.Push("lexIntervalUnit", lexIntervalUnit)
+		return LexValue
+	}
+	typ, ok := scanNumber(l)
+	if !ok {
+		return l.errorf("bad number syntax: %q", l.input[l.start:l.pos])
+	}
+	l.Emit(typ)
+	return lexIntervalUnit
+}
+
+// lexIntervalUnit the DAY, HOUR etc unit of an INTERVAL
+func lexIntervalUnit(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	word := l.PeekWord()
+	if intervalUnits[strings.ToLower(word)] {
+		l.ConsumeWord(word)
+		l.Emit(TokenIntervalUnit)
+	}
+	return nil
+}
+
+// LexCase lexes a CASE expression, either simple (with an operand compared
+// to each WHEN) or searched (each WHEN is a condition)
+//
+//    CASE [<expr>] WHEN <expr> THEN <expr> [WHEN <expr> THEN <expr>]* [ELSE <expr>] END
+//
+//    CASE WHEN score > 90 THEN 'A' ELSE 'B' END
+//    CASE status WHEN 1 THEN 'active' END
+//
+func LexCase(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	word := l.PeekWord()
+	if strings.ToLower(word) != "case" {
+		return l.errorf("expected CASE but got %q", word)
+	}
+	l.ConsumeWord(word)
+	l.Emit(TokenCase)
+	l.caseStack = append(l.caseStack, TokenCase)
+	l.Push("lexCaseClause", lexCaseClause)
+	l.SkipWhiteSpaces()
+	if strings.ToLower(l.PeekWord()) == "when" {
+		return nil
+	}
+	// the operand of a simple case
+	return LexExpression
+}
+
+// lexCaseClause lexes the WHEN, THEN, ELSE or END keyword that must follow
+// the last part of the CASE expression being lexed, and the part after it
+func lexCaseClause(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	word := l.PeekWord()
+	last := len(l.caseStack) - 1
+	var expected string
+	var t TokenType
+	switch l.caseStack[last] {
+	case TokenCase:
+		expected, t = "WHEN", caseKeyword(word, TokenWhen)
+	case TokenWhen:
+		expected, t = "THEN", caseKeyword(word, TokenThen)
+	case TokenThen:
+		expected, t = "WHEN, ELSE or END", caseKeyword(word, TokenWhen, TokenElse, TokenEnd)
+	case TokenElse:
+		expected, t = "END", caseKeyword(word, TokenEnd)
+	}
+	if t == TokenNil {
+		if l.IsEnd() {
+			return l.errorf("expected %s in CASE but got EOF", expected)
+		}
+		return l.errorf("expected %s in CASE but got %q", expected, word)
+	}
+	l.ConsumeWord(word)
+	l.Emit(t)
+	if t == TokenEnd {
+		l.caseStack = l.caseStack[:last]
+		return nil
+	}
+	l.caseStack[last] = t
+	l.Push("lexCaseClause", lexCaseClause)
+	return LexExpression
+}
+
+// caseKeyword the token of word, if it is one of the allowed keywords
+func caseKeyword(word string, allowed ...TokenType) TokenType {
+	for _, t := range allowed {
+		if strings.EqualFold(word, t.String()) {
+			return t
+		}
+	}
+	return TokenNil
 }
 
 // Handle columnar identies with keyword appendate (ASC, DESC)
@@ -2696,12 +4116,18 @@ func LexOrderByColumn(l *Lexer) StateFn {
 		l.ConsumeWord(word)
 		l.Emit(TokenDesc)
 		return LexOrderByColumn
+	case "nulls":
+		if l.dialect.NullsOrdering && l.lexNullsOrder() {
+			return LexOrderByColumn
+		}
+		l.Push("LexOrderByColumn", LexOrderByColumn)
+		return LexExpressionOrIdentity
 	default:
 		if len(l.stack) < 2 {
 			l.Push("LexOrderByColumn", LexOrderByColumn)
 			return LexExpressionOrIdentity
 		} else {
-			u.Errorf("Gracefully refusing to add more LexOrderByColumn: ")
+			debugf("Gracefully refusing to add more LexOrderByColumn: ")
 		}
 	}
 
@@ -2709,6 +4135,41 @@ func LexOrderByColumn(l *Lexer) StateFn {
 	return nil
 }
 
+// lexNullsOrder lexes the NULLS FIRST or NULLS LAST of an order by column,
+// if the word after nulls is neither it is left alone as it is a column
+//
+//    ORDER BY score DESC NULLS LAST
+//
+func (l *Lexer) lexNullsOrder() bool {
+	next := l.wordAfter("nulls")
+	tok := TokenFirst
+	switch strings.ToLower(next) {
+	case "first":
+	case "last":
+		tok = TokenLast
+	default:
+		return false
+	}
+	l.ConsumeWord("nulls")
+	l.Emit(TokenNulls)
+	l.SkipWhiteSpaces()
+	l.ConsumeWord(next)
+	l.Emit(tok)
+	return true
+}
+
+// wordAfter returns, without consuming anything, the word following the
+// word at the current position, to tell apart keywords that are only
+// keywords when followed by another
+func (l *Lexer) wordAfter(word string) string {
+	after := strings.TrimLeftFunc(l.input[l.pos+len(word):], unicode.IsSpace)
+	n := 0
+	for n < len(after) && IsIdentifierRune(rune(after[n])) {
+		n++
+	}
+	return after[:n]
+}
+
 // Lex either Json or Key/Value pairs
 //
 //    Must start with { or [ for json
@@ -2788,7 +4249,7 @@ func LexJsonValue(l *Lexer) StateFn {
 		l.Emit(TokenLeftBracket)
 		return LexJsonArray
 	case ',':
-		u.Warnf("Should not be possible to get comma here?")
+		debugf("Should not be possible to get comma here?")
 	default:
 		return LexValue(l)
 	}
@@ -2875,7 +4336,7 @@ func LexJsonObject(l *Lexer) StateFn {
 		return LexJsonArray
 	}
 
-	u.Warnf("Did not find json? %v", l.PeekX(20))
+	debugf("Did not find json? %v", l.PeekX(20))
 	return nil
 }
 
@@ -2930,8 +4391,8 @@ func LexJsonIdentity(l *Lexer) StateFn {
 					return nil
 				}
 			}
-			if rune == 0 {
-				return l.errorToken("string value was not delimited")
+			if rune == eof {
+				return l.errorToken("reached end without finding end for quoted value")
 			}
 			previousEscaped = rune == '\\'
 		}
@@ -2960,7 +4421,7 @@ func LexComment(l *Lexer) StateFn {
 	} else if strings.HasPrefix(l.input[l.pos:], "--") {
 		//u.Debugf("found single line comment:  -- ")
 		return LexInlineComment(l)
-	} else if strings.HasPrefix(l.input[l.pos:], "#") {
+	} else if l.dialect.HashComments && strings.HasPrefix(l.input[l.pos:], "#") {
 		//u.Debugf("found single line comment:  # ")
 		return LexInlineComment(l)
 	}
@@ -2968,10 +4429,19 @@ func LexComment(l *Lexer) StateFn {
 }
 
 // A multi-line comment of format /* comment */
-// it does not have to actually be multi-line, just surrounded by those comments
+// it does not have to actually be multi-line, just surrounded by those comments.
+// A comment beginning with /*+ is an optimizer hint, emitted as a TokenHint
+// of the trimmed text between the delimiters
+//
+//    SELECT /*+ shard(us-east) */ * FROM t     -> TokenHint "shard(us-east)"
+//
 func LexMultilineComment(l *Lexer) StateFn {
 	// Consume opening "/*"
 	l.ignoreWord("/*")
+	hint := strings.HasPrefix(l.input[l.pos:], "+")
+	if hint {
+		l.ignoreWord("+")
+	}
 	for {
 		if strings.HasPrefix(l.input[l.pos:], "*/") {
 			break
@@ -2981,7 +4451,11 @@ func LexMultilineComment(l *Lexer) StateFn {
 			return l.errorf("unexpected eof in comment: %q", l.input)
 		}
 	}
-	l.Emit(TokenCommentML)
+	if hint {
+		l.EmitValue(TokenHint, strings.TrimSpace(l.input[l.start:l.pos]))
+	} else {
+		l.Emit(TokenCommentML)
+	}
 	// Consume trailing "*/"
 	l.ignoreWord("*/")
 	return nil
@@ -3112,7 +4586,6 @@ func LexNumber(l *Lexer) StateFn {
 func LexNumberOrDuration(l *Lexer) StateFn {
 	l.SkipWhiteSpaces()
 	typ, ok := scanNumericOrDuration(l, true)
-	u.Debugf("typ%T   %v", typ, ok)
 	if !ok {
 		return l.errorf("bad number syntax: %q", l.input[l.start:l.pos])
 	}
@@ -3134,6 +4607,24 @@ func LexDuration(l *Lexer) StateFn {
 	return nil
 }
 
+// LexInteger a non-negative integer, such as the row-counts of LIMIT, OFFSET
+//
+//  100
+//  0
+//
+func LexInteger(l *Lexer) StateFn {
+	l.SkipWhiteSpaces()
+	if !isDigit(l.Peek()) {
+		return l.errorf("expected non-negative integer but got %q", l.PeekWord())
+	}
+	typ, ok := scanNumber(l)
+	if !ok || typ != TokenInteger {
+		return l.errorf("expected non-negative integer but got %q", l.input[l.start:l.pos])
+	}
+	l.Emit(TokenInteger)
+	return nil
+}
+
 // scan for a number
 //
 // It returns the scanned tokenType (tokenFloat or tokenInteger) and a flag
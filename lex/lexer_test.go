@@ -107,6 +107,78 @@ func TestLexValue(t *testing.T) {
 	tok = token(`"Toys R"" Us"`, LexValue)
 	assert.True(t, tok.T == TokenValueEscaped, "%v", tok)
 	assert.True(t, tok.V == `Toys R"" Us`, "%v", tok.String())
+
+	// un-escaped values for both quote styles
+	for quoted, expects := range map[string]string{
+		`'it''s'`:               `it's`,
+		`'it\'s'`:               `it's`,
+		`'''quoted'''`:          `'quoted'`,
+		`"she said ""hi"""`:     `she said "hi"`,
+		`"she said \"hi\""`:     `she said "hi"`,
+		`'she said "hi"'`:       `she said "hi"`,
+		`"it's"`:                `it's`,
+		`'tab\there'`:           `tab\there`,
+		`'ünïcode''s «quotes»'`: `ünïcode's «quotes»`,
+	} {
+		tok = token(quoted, LexValue)
+		assert.Equal(t, expects, tok.UnEscapedValue(), "%s  %v", quoted, tok)
+		assert.Equal(t, quoted[0], tok.Quote, "%v", tok)
+	}
+}
+
+func TestLexValueBackslashEscapes(t *testing.T) {
+	mysql := &Dialect{Name: "mysql", Statements: SqlDialect.Statements, BackslashEscapes: true}
+	lexValue := func(d *Dialect, input string) Token {
+		l := NewLexer(input, d)
+		LexValue(l)
+		return l.NextToken()
+	}
+
+	for quoted, expects := range map[string]string{
+		`'line1\nline2'`: "line1\nline2",
+		`'a\tb'`:         "a\tb",
+		`'c:\\temp'`:     `c:\temp`,
+		`'it\'s'`:        `it's`,
+		`'it''s'`:        `it's`,
+		`"say \"hi\""`:   `say "hi"`,
+		`'\d+'`:          `\d+`,
+	} {
+		tok := lexValue(mysql, quoted)
+		assert.Equal(t, TokenValue, tok.T, "%s  %v", quoted, tok)
+		assert.Equal(t, expects, tok.V, "%s  %v", quoted, tok)
+		assert.Equal(t, quoted[0], tok.Quote, "%v", tok)
+	}
+	tok := lexValue(mysql, `'unterminated\'`)
+	assert.Equal(t, TokenError, tok.T, "%v", tok)
+
+	// default dialect leaves backslashes as is
+	tok = lexValue(SqlDialect, `'line1\nline2'`)
+	assert.Equal(t, TokenValue, tok.T, "%v", tok)
+	assert.Equal(t, `line1\nline2`, tok.V)
+	tok = lexValue(SqlDialect, `'c:\\temp'`)
+	assert.Equal(t, `c:\\temp`, tok.V)
+
+	l := NewLexer(`SELECT a FROM t WHERE b = 'x\ty' AND c = 1`, mysql)
+	tokens := []Token{
+		tv(TokenSelect, "SELECT"),
+		tv(TokenIdentity, "a"),
+		tv(TokenFrom, "FROM"),
+		tv(TokenIdentity, "t"),
+		tv(TokenWhere, "WHERE"),
+		tv(TokenIdentity, "b"),
+		tv(TokenEqual, "="),
+		tv(TokenValue, "x\ty"),
+		tv(TokenLogicAnd, "AND"),
+		tv(TokenIdentity, "c"),
+		tv(TokenEqual, "="),
+		tv(TokenInteger, "1"),
+		tv(TokenEOF, ""),
+	}
+	for _, expects := range tokens {
+		tok := l.NextToken()
+		assert.Equal(t, expects.T, tok.T, "%v", tok)
+		assert.Equal(t, expects.V, tok.V, "%v", tok)
+	}
 }
 
 func TestLexRegex(t *testing.T) {
@@ -298,6 +370,25 @@ SELECT x FROM mytable`,
 			tv(TokenIdentity, "mytable"),
 		})
 
+	// ansi sql does not have # comments
+	ansi := &Dialect{Name: "ansi", Statements: SqlDialect.Statements}
+	l := NewLexer(`# with hash
+SELECT x FROM mytable`, ansi)
+	tok := l.NextToken()
+	assert.Equal(t, TokenError, tok.T, "%v", tok)
+	assert.True(t, strings.Contains(tok.V, "# comments are not enabled"), "%v", tok)
+	l = NewLexer(`-- dashes are fine
+SELECT x FROM mytable`, ansi)
+	verifyLexerTokens(t, l,
+		[]Token{
+			tv(TokenCommentSingleLine, "--"),
+			tv(TokenComment, " dashes are fine"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "x"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "mytable"),
+		})
+
 	verifyTokens(t, `/*
 hello
 multiline
@@ -314,6 +405,193 @@ SELECT x FROM mytable`,
 		})
 }
 
+func TestLexCommentsMidStatement(t *testing.T) {
+	verifyTokens(t, `SELECT x /* note */ FROM t`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "x"),
+			tv(TokenCommentML, " note "),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT x FROM t WHERE a = /* one */ 1 -- and two
+		AND b > 2 /* done */`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "x"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "a"),
+			tv(TokenEqual, "="),
+			tv(TokenCommentML, " one "),
+			tv(TokenInteger, "1"),
+			tv(TokenCommentSingleLine, "--"),
+			tv(TokenComment, " and two"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "b"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "2"),
+			tv(TokenCommentML, " done "),
+			tv(TokenEOF, ""),
+		})
+	verifyTokenTypes(t, `SELECT a, /* b */ b FROM /* t */ t /* where */ WHERE a /* minus */ - 1 > 0`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenComma, TokenCommentML, TokenIdentity, TokenFrom,
+			TokenCommentML, TokenIdentity, TokenCommentML, TokenWhere, TokenIdentity, TokenCommentML,
+			TokenMinus, TokenInteger, TokenGT, TokenInteger, TokenEOF,
+		})
+
+	_, err := Tokenize(`SELECT x FROM t WHERE a = 1 /* not closed`)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestLexCommentTrivia(t *testing.T) {
+	l := NewSqlLexer(`-- the users
+SELECT x /* note */ FROM t
+	WHERE a = /* one */ 1 # and two
+	AND b > 2 // done`)
+	l.CommentTrivia = true
+	toks := l.AppendTokens(nil)
+	types := make([]TokenType, len(toks))
+	for i, tok := range toks {
+		types[i] = tok.T
+	}
+	assert.Equal(t, []TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere, TokenIdentity,
+		TokenEqual, TokenInteger, TokenLogicAnd, TokenIdentity, TokenGT, TokenInteger, TokenEOF}, types)
+	assert.Equal(t, []string{"-- the users"}, l.Comments(toks[0]))
+	assert.Equal(t, 0, len(l.Comments(toks[1])))
+	assert.Equal(t, []string{"/* note */"}, l.Comments(toks[2]))
+	assert.Equal(t, []string{"/* one */"}, l.Comments(toks[7]))
+	assert.Equal(t, []string{"# and two"}, l.Comments(toks[8]))
+	assert.Equal(t, []string{"// done"}, l.Comments(toks[12]))
+
+	// several comments in a row are all attached, hints are still tokens
+	l = NewSqlLexer(`SELECT /*+ shard(a) */ /* one */ -- two
+		x FROM t`)
+	l.CommentTrivia = true
+	verifyLexerTokens(t, l,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenHint, "shard(a)"),
+			tv(TokenIdentity, "x"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+		})
+	l.Reset(`SELECT /* one */ -- two
+		x FROM t`)
+	l.NextToken()
+	assert.Equal(t, []string{"/* one */", "-- two"}, l.Comments(l.NextToken()))
+
+	// including those in sub-queries
+	l.Reset(`SELECT a FROM t WHERE x IN (SELECT /* c */ b FROM u -- end
+		) AND y = 1`)
+	toks = l.AppendTokens(nil)
+	types = types[:0]
+	for _, tok := range toks {
+		types = append(types, tok.T)
+	}
+	assert.Equal(t, []TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenWhere, TokenIdentity,
+		TokenIN, TokenLeftParenthesis, TokenSelect, TokenIdentity, TokenFrom, TokenIdentity, TokenRightParenthesis,
+		TokenLogicAnd, TokenIdentity, TokenEqual, TokenInteger, TokenEOF}, types)
+	assert.Equal(t, []string{"/* c */"}, l.Comments(toks[9]))
+	assert.Equal(t, []string{"-- end"}, l.Comments(toks[12]))
+
+	// by default they are tokens
+	l = NewSqlLexer(`SELECT x /* note */ FROM t`)
+	assert.Equal(t, 0, len(l.Comments(l.NextToken())))
+	assert.Equal(t, 0, len(l.Comments(l.NextToken())))
+	assert.Equal(t, TokenCommentML, l.NextToken().T)
+}
+
+func TestLexHints(t *testing.T) {
+	verifyTokens(t, `SELECT /*+ shard(us-east) */ * FROM t`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenHint, "shard(us-east)"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `/*+ shard(us-east) index(t idx_a) */
+SELECT a FROM t`,
+		[]Token{
+			tv(TokenHint, "shard(us-east) index(t idx_a)"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+
+	// plain comments are not hints, even if they mention a +
+	verifyTokens(t, `SELECT /* a + b */ a FROM t`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenCommentML, " a + b "),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexMysqlVersionComments(t *testing.T) {
+	mysql := &Dialect{Name: "mysql", Statements: SqlDialect.Statements, MysqlVersionComments: true}
+	mysql.Init()
+
+	// a gated statement, as in a mysqldump
+	verifyLexerTokens(t, NewLexer(`/*!40101 SET NAMES utf8 */;
+SELECT a FROM t`, mysql),
+		[]Token{
+			tv(TokenMysqlVersionComment, "40101"),
+			tv(TokenSet, "SET"),
+			tv(TokenIdentity, "NAMES"),
+			tv(TokenIdentity, "utf8"),
+			tv(TokenCommentEnd, "*/"),
+			tv(TokenEOS, ";"),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+
+	// a gated fragment of a larger statement
+	verifyLexerTokens(t, NewLexer(`SELECT /*!40001 SQL_NO_CACHE */ * FROM t WHERE x = 1 /*!50000 AND y = 2 */`, mysql),
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenMysqlVersionComment, "40001"),
+			tv(TokenIdentity, "SQL_NO_CACHE"),
+			tv(TokenCommentEnd, "*/"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenMysqlVersionComment, "50000"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "y"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "2"),
+			tv(TokenCommentEnd, "*/"),
+			tv(TokenEOF, ""),
+		})
+
+	_, err := TokenizeDialect(`/*!40101 SET NAMES utf8`, mysql)
+	assert.NotEqual(t, nil, err)
+
+	// without the option they are ordinary comments
+	verifyTokens(t, `/*!40101 SET NAMES utf8 */;`,
+		[]Token{
+			tv(TokenCommentML, "!40101 SET NAMES utf8 "),
+			tv(TokenEOF, ""),
+		})
+}
+
 func TestLexSqlIdentities(t *testing.T) {
 	// http://stackoverflow.com/questions/1992314/what-is-the-difference-between-single-and-double-quotes-in-sql
 	// Verify a variety of things in identities
@@ -360,6 +638,21 @@ func TestLexSqlIdentities(t *testing.T) {
 			tv(TokenFrom, "from"),
 			tv(TokenIdentity, "table name"),
 		})
+
+	// a doubled closing delimiter is an escaped delimiter in the name
+	verifyTokens(t, "select [a]]b], `c``d` AS [e]]], [f]]].* from [tbl]]1]",
+		[]Token{
+			tv(TokenSelect, "select"),
+			tv(TokenIdentity, "a]b"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "c`d"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "e]"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "f].*"),
+			tv(TokenFrom, "from"),
+			tv(TokenIdentity, "tbl]1"),
+		})
 }
 
 func TestWithDialect(t *testing.T) {
@@ -368,7 +661,9 @@ func TestWithDialect(t *testing.T) {
 		{Token: TokenWith, Lexer: LexColumns, Optional: true},
 	}}
 	withDialect := &Dialect{
-		"QL With", []*Clause{withStatement}, IdentityQuoting, false,
+		Name:            "QL With",
+		Statements:      []*Clause{withStatement},
+		IdentityQuoting: IdentityQuoting,
 	}
 	withDialect.Init()
 	/* Many *ql languages support some type of columnar layout such as:
@@ -601,7 +896,7 @@ func TestLexUdfSpecialAs(t *testing.T) {
 		[]TokenType{TokenSelect,
 			TokenIdentity, TokenComma,
 			TokenUdfExpr, TokenLeftParenthesis, TokenIdentity, TokenAs,
-			TokenIdentity, TokenRightParenthesis,
+			TokenTypeDef, TokenRightParenthesis,
 			TokenFrom, TokenIdentity,
 		})
 }
@@ -620,6 +915,109 @@ func TestLexSqlJoin(t *testing.T) {
 			TokenInner, TokenJoin, TokenIdentity, TokenAs, TokenIdentity,
 			TokenOn, TokenIdentity, TokenEqual, TokenIdentity,
 		})
+
+	// aliases without AS, bare JOIN
+	verifyTokens(t, `SELECT u.name, o.total FROM users u INNER JOIN orders o ON u.id = o.user_id WHERE o.total > 10`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "u.name"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "o.total"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "users"),
+			tv(TokenIdentity, "u"),
+			tv(TokenInner, "INNER"),
+			tv(TokenJoin, "JOIN"),
+			tv(TokenIdentity, "orders"),
+			tv(TokenIdentity, "o"),
+			tv(TokenOn, "ON"),
+			tv(TokenIdentity, "u.id"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "o.user_id"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "o.total"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "10"),
+		})
+
+	// chained joins
+	verifyTokens(t, `SELECT a FROM t1 JOIN t2 ON t1.id = t2.id INNER JOIN t3 AS c ON c.id = t2.id AND c.x = 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t1"),
+			tv(TokenJoin, "JOIN"),
+			tv(TokenIdentity, "t2"),
+			tv(TokenOn, "ON"),
+			tv(TokenIdentity, "t1.id"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "t2.id"),
+			tv(TokenInner, "INNER"),
+			tv(TokenJoin, "JOIN"),
+			tv(TokenIdentity, "t3"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "c"),
+			tv(TokenOn, "ON"),
+			tv(TokenIdentity, "c.id"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "t2.id"),
+			tv(TokenLogicAnd, "AND"),
+			tv(TokenIdentity, "c.x"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexSqlJoinTypes(t *testing.T) {
+	joins := map[string][]TokenType{
+		"LEFT JOIN":        {TokenLeft, TokenJoin},
+		"LEFT OUTER JOIN":  {TokenLeft, TokenOuter, TokenJoin},
+		"RIGHT JOIN":       {TokenRight, TokenJoin},
+		"RIGHT OUTER JOIN": {TokenRight, TokenOuter, TokenJoin},
+		"FULL OUTER JOIN":  {TokenFull, TokenOuter, TokenJoin},
+		"INNER JOIN":       {TokenInner, TokenJoin},
+	}
+	for join, joinTokens := range joins {
+		tokens := []TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity}
+		tokens = append(tokens, joinTokens...)
+		tokens = append(tokens, TokenIdentity, TokenIdentity, TokenOn, TokenIdentity, TokenEqual, TokenIdentity, TokenEOF)
+		verifyTokenTypes(t, "SELECT a FROM t1 "+join+" t2 b ON t1.id = b.id", tokens)
+	}
+
+	// cross join has no ON
+	verifyTokenTypes(t, `SELECT a FROM t1 CROSS JOIN t2 WHERE t1.x = t2.x`,
+		[]TokenType{TokenSelect, TokenIdentity, TokenFrom, TokenIdentity,
+			TokenCross, TokenJoin, TokenIdentity,
+			TokenWhere, TokenIdentity, TokenEqual, TokenIdentity, TokenEOF,
+		})
+
+	// join keywords are columns in the select list, and mixing LEFT and INNER
+	verifyTokens(t, `SELECT left, right FROM t1 LEFT JOIN t2 ON t1.id = t2.id INNER JOIN t3 ON t3.id = t2.id`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "left"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "right"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t1"),
+			tv(TokenLeft, "LEFT"),
+			tv(TokenJoin, "JOIN"),
+			tv(TokenIdentity, "t2"),
+			tv(TokenOn, "ON"),
+			tv(TokenIdentity, "t1.id"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "t2.id"),
+			tv(TokenInner, "INNER"),
+			tv(TokenJoin, "JOIN"),
+			tv(TokenIdentity, "t3"),
+			tv(TokenOn, "ON"),
+			tv(TokenIdentity, "t3.id"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "t2.id"),
+			tv(TokenEOF, ""),
+		})
 }
 
 func TestLexSqlSubQuery(t *testing.T) {
@@ -639,6 +1037,68 @@ func TestLexSqlSubQuery(t *testing.T) {
 		})
 }
 
+func TestLexSqlFromSubQuery(t *testing.T) {
+
+	verifyTokens(t, `SELECT t.a FROM (SELECT a FROM users WHERE x > 1) AS t`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "t.a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "users"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "x"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOF, ""),
+		})
+
+	// nested two deep
+	verifyTokens(t, `SELECT t.a FROM (
+			SELECT a FROM (SELECT a, b FROM users) AS u WHERE u.b > 1
+		) AS t WHERE t.a = 2`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "t.a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "users"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "u"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "u.b"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenAs, "AS"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "t.a"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "2"),
+			tv(TokenEOF, ""),
+		})
+
+	toks := lexTokens(`SELECT a FROM (SELECT a FROM users`)
+	assert.Equal(t, TokenError, toks[len(toks)-1].T)
+}
+
 func TestLexSqlPreparedStmt(t *testing.T) {
 	verifyTokens(t, `
 		PREPARE stmt1 
@@ -651,6 +1111,47 @@ func TestLexSqlPreparedStmt(t *testing.T) {
 			tv(TokenValue, `SELECT SQRT(POW(?,2) + POW(?,2)) AS hypotenuse`),
 			tv(TokenEOS, ";"),
 		})
+	verifyTokens(t, `PREPARE stmt1 FROM 'SELECT * FROM t WHERE id = ?'`,
+		[]Token{
+			tv(TokenPrepare, "PREPARE"),
+			tv(TokenIdentity, "stmt1"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenValue, `SELECT * FROM t WHERE id = ?`),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `EXECUTE stmt1 USING @a`,
+		[]Token{
+			tv(TokenExecute, "EXECUTE"),
+			tv(TokenIdentity, "stmt1"),
+			tv(TokenUsing, "USING"),
+			tv(TokenVariable, "@a"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `EXECUTE stmt1 USING @a, @b; EXECUTE stmt2; DEALLOCATE PREPARE stmt1;`,
+		[]Token{
+			tv(TokenExecute, "EXECUTE"),
+			tv(TokenIdentity, "stmt1"),
+			tv(TokenUsing, "USING"),
+			tv(TokenVariable, "@a"),
+			tv(TokenComma, ","),
+			tv(TokenVariable, "@b"),
+			tv(TokenEOS, ";"),
+			tv(TokenExecute, "EXECUTE"),
+			tv(TokenIdentity, "stmt2"),
+			tv(TokenEOS, ";"),
+			tv(TokenDeallocate, "DEALLOCATE"),
+			tv(TokenPrepare, "PREPARE"),
+			tv(TokenIdentity, "stmt1"),
+			tv(TokenEOS, ";"),
+		})
+
+	verifyTokenTypes(t, `EXECUTE stmt1 USING ?, @b`,
+		[]TokenType{TokenExecute, TokenIdentity, TokenUsing, TokenPlaceholder, TokenComma, TokenVariable, TokenEOF})
+
+	for _, sql := range []string{`EXECUTE`, `EXECUTE s USING`, `EXECUTE s USING 1`, `EXECUTE s x`, `DEALLOCATE s`} {
+		_, err := Tokenize(sql)
+		assert.NotEqual(t, nil, err, sql)
+	}
 }
 
 func TestLexGroupBy(t *testing.T) {
@@ -695,40 +1196,179 @@ func TestLexGroupBy(t *testing.T) {
 			tv(TokenRightParenthesis, ")"),
 			tv(TokenRightParenthesis, ")"),
 		})
-}
-
-func TestLexFrom(t *testing.T) {
-	verifyTokens(t, `SELECT x FROM github.user`,
+	// positional references to the select list
+	verifyTokens(t, `SELECT a, b FROM p GROUP BY 1, 2 LIMIT 5`,
 		[]Token{
 			tv(TokenSelect, "SELECT"),
-			tv(TokenIdentity, "x"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
 			tv(TokenFrom, "FROM"),
-			tv(TokenIdentity, "github.user"),
+			tv(TokenIdentity, "p"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "2"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "5"),
+			tv(TokenEOF, ""),
 		})
-	verifyTokens(t, `SELECT x FROM github/user`,
+	// a mix of expressions, positions and identities
+	verifyTokens(t, `SELECT d, count(*) FROM p GROUP BY date_trunc('day', ts), 1, category
+		HAVING count(*) > 1 ORDER BY 1`,
 		[]Token{
 			tv(TokenSelect, "SELECT"),
-			tv(TokenIdentity, "x"),
+			tv(TokenIdentity, "d"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
 			tv(TokenFrom, "FROM"),
-			tv(TokenIdentity, "github/user"),
+			tv(TokenIdentity, "p"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenUdfExpr, "date_trunc"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenValue, "day"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "ts"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "category"),
+			tv(TokenHaving, "HAVING"),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
 		})
-}
-
-func TestLexOrderBy(t *testing.T) {
-	verifyTokens(t, `
-	SELECT product_id, name, category
-	FROM product
-	WHERE status = "instock"
-	ORDER BY category, otherstuff;`,
+	// parenthesized grouping sets, and expressions starting with a paren
+	verifyTokens(t, `SELECT a FROM p GROUP BY (a, b), c, (lower(d)), (), (a + b) * 2 ORDER BY a`,
 		[]Token{
 			tv(TokenSelect, "SELECT"),
-			tv(TokenIdentity, "product_id"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "p"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "a"),
 			tv(TokenComma, ","),
-			tv(TokenIdentity, "name"),
+			tv(TokenIdentity, "b"),
+			tv(TokenRightParenthesis, ")"),
 			tv(TokenComma, ","),
-			tv(TokenIdentity, "category"),
-			tv(TokenFrom, "FROM"),
-			tv(TokenIdentity, "product"),
+			tv(TokenIdentity, "c"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenUdfExpr, "lower"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "d"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "a"),
+			tv(TokenPlus, "+"),
+			tv(TokenIdentity, "b"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenMultiply, "*"),
+			tv(TokenInteger, "2"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenEOF, ""),
+		})
+	_, err := Tokenize(`SELECT a FROM p GROUP BY (a, b`)
+	assert.NotEqual(t, nil, err)
+
+	// MySQL WITH ROLLUP, then on to HAVING and ORDER BY as usual
+	verifyTokens(t, `SELECT a, b, count(*) FROM p GROUP BY a, b WITH ROLLUP HAVING count(*) > 1 ORDER BY a`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "p"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
+			tv(TokenWith, "WITH"),
+			tv(TokenRollup, "ROLLUP"),
+			tv(TokenHaving, "HAVING"),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenEOF, ""),
+		})
+
+	// any other WITH is still the trailing WITH properties
+	verifyTokens(t, `SELECT a FROM p GROUP BY a WITH rollup = 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "p"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenWith, "WITH"),
+			tv(TokenIdentity, "rollup"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexFrom(t *testing.T) {
+	verifyTokens(t, `SELECT x FROM github.user`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "x"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "github.user"),
+		})
+	verifyTokens(t, `SELECT x FROM github/user`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "x"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "github/user"),
+		})
+}
+
+func TestLexOrderBy(t *testing.T) {
+	verifyTokens(t, `
+	SELECT product_id, name, category
+	FROM product
+	WHERE status = "instock"
+	ORDER BY category, otherstuff;`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "product_id"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "name"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "category"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "product"),
 			tv(TokenWhere, "WHERE"),
 			tv(TokenIdentity, "status"),
 			tv(TokenEqual, "="),
@@ -757,6 +1397,433 @@ func TestLexOrderBy(t *testing.T) {
 			tv(TokenAsc, "ASC"),
 			tv(TokenEOS, ";"),
 		})
+
+	// positions in the select list
+	verifyTokens(t, `SELECT a, count(*) FROM t GROUP BY a ORDER BY 2 DESC, 1 LIMIT 5`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenUdfExpr, "count"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenStar, "*"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenInteger, "2"),
+			tv(TokenDesc, "DESC"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "1"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "5"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexLimit(t *testing.T) {
+	verifyTokens(t, `SELECT * FROM t LIMIT 10`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "10"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT * FROM t ORDER BY x LIMIT 10 OFFSET 20`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "x"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "10"),
+			tv(TokenOffset, "OFFSET"),
+			tv(TokenInteger, "20"),
+			tv(TokenEOF, ""),
+		})
+	// mysql style   LIMIT offset, row_count
+	verifyTokens(t, `SELECT * FROM t GROUP BY x LIMIT 20, 10;`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenStar, "*"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenGroupBy, "GROUP BY"),
+			tv(TokenIdentity, "x"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "20"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "10"),
+			tv(TokenEOS, ";"),
+		})
+	// SKIP is a synonym of OFFSET
+	verifyTokens(t, `SELECT x FROM t SKIP 10`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "x"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenSkip, "SKIP"),
+			tv(TokenInteger, "10"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT x FROM t WHERE y > 1 LIMIT 5 skip 10`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "x"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "y"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "5"),
+			tv(TokenSkip, "skip"),
+			tv(TokenInteger, "10"),
+			tv(TokenEOF, ""),
+		})
+	// but only before a row count, otherwise it is a column
+	verifyTokens(t, `SELECT skip FROM t WHERE skip > 1`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "skip"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "skip"),
+			tv(TokenGT, ">"),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+	// ansi OFFSET ... FETCH pagination
+	verifyTokens(t, `SELECT a FROM t ORDER BY a OFFSET 10 ROWS FETCH FIRST 20 ROWS ONLY`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenOffset, "OFFSET"),
+			tv(TokenInteger, "10"),
+			tv(TokenRows, "ROWS"),
+			tv(TokenFetch, "FETCH"),
+			tv(TokenFirst, "FIRST"),
+			tv(TokenInteger, "20"),
+			tv(TokenRows, "ROWS"),
+			tv(TokenOnly, "ONLY"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT a FROM t ORDER BY a OFFSET 1 ROW`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenOffset, "OFFSET"),
+			tv(TokenInteger, "1"),
+			tv(TokenRows, "ROW"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `SELECT a FROM t ORDER BY a FETCH NEXT ROW ONLY`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenOrderBy, "ORDER BY"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFetch, "FETCH"),
+			tv(TokenNext, "NEXT"),
+			tv(TokenRows, "ROW"),
+			tv(TokenOnly, "ONLY"),
+			tv(TokenEOF, ""),
+		})
+	for _, sql := range []string{
+		`SELECT a FROM t ORDER BY a FETCH FIRST 5 ONLY`,
+		`SELECT a FROM t ORDER BY a FETCH FIRST 5 ROWS`,
+		`SELECT a FROM t ORDER BY a OFFSET 5 ROWS FETCH FIRST 1.5 ROWS ONLY`,
+	} {
+		_, err := Tokenize(sql)
+		assert.NotEqual(t, nil, err, sql)
+	}
+
+	verifyTokens(t, `SELECT a FROM t WHERE a IN (SELECT b FROM u LIMIT 5)`,
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenWhere, "WHERE"),
+			tv(TokenIdentity, "a"),
+			tv(TokenIN, "IN"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "b"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "u"),
+			tv(TokenLimit, "LIMIT"),
+			tv(TokenInteger, "5"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+
+	// Only non-negative integers are valid row counts
+	for _, sql := range []string{
+		`SELECT * FROM t LIMIT abc`,
+		`SELECT * FROM t LIMIT -5`,
+		`SELECT * FROM t LIMIT 1.5`,
+		`SELECT * FROM t LIMIT 10 OFFSET x`,
+		`SELECT a FROM t WHERE a IN (SELECT b FROM u LIMIT 1.5)`,
+		`INSERT INTO t SELECT a FROM u LIMIT 1.5`,
+		`DELETE FROM t WHERE a = 1 LIMIT 1.5`,
+		`UPDATE t SET a = 1 LIMIT 1.5`,
+	} {
+		toks := lexTokens(sql)
+		tok := toks[len(toks)-1]
+		assert.Equal(t, TokenError, tok.T, "expected error for %q got %v", sql, tok)
+	}
+	toks := lexTokens(`SELECT * FROM t LIMIT abc`)
+	tok := toks[len(toks)-1]
+	assert.Equal(t, 1, tok.Line)
+	assert.Equal(t, 22, tok.Column)
+	assert.Equal(t, 22, tok.Pos)
+}
+
+func TestLexEmpty(t *testing.T) {
+	// no statement at all is a single clean EOF, not an error
+	for _, sql := range []string{"", "   ", " \n\t \r\n "} {
+		l := NewSqlLexer(sql)
+		tok := l.NextToken()
+		assert.Equal(t, TokenEOF, tok.T, "sql=%q", sql)
+		assert.Equal(t, nil, l.Err())
+		assert.Equal(t, TokenEOF, l.NextToken().T)
+
+		toks, err := Tokenize(sql)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 0, len(toks))
+	}
+
+	// nor are comments with no statement after them
+	verifyTokens(t, `-- just a comment`,
+		[]Token{
+			tv(TokenCommentSingleLine, "--"),
+			tv(TokenComment, " just a comment"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, "SELECT a FROM t; \n  ",
+		[]Token{
+			tv(TokenSelect, "SELECT"),
+			tv(TokenIdentity, "a"),
+			tv(TokenFrom, "FROM"),
+			tv(TokenIdentity, "t"),
+			tv(TokenEOS, ";"),
+			tv(TokenEOF, ""),
+		})
+}
+
+func TestLexError(t *testing.T) {
+	l := NewSqlLexer("SELECT a\nFROM t\nWHERE x = 'unterminated")
+	var tok Token
+	for tok = l.NextToken(); tok.T != TokenError && tok.T != TokenEOF; tok = l.NextToken() {
+	}
+	assert.Equal(t, TokenError, tok.T)
+	err := l.Err()
+	assert.NotEqual(t, nil, err)
+	le, ok := err.(*LexError)
+	assert.True(t, ok, "expected *LexError got %T", err)
+	assert.Equal(t, tok.V, le.Msg)
+	assert.Equal(t, 3, le.Line)
+	assert.Equal(t, 23, le.Column)
+	assert.Equal(t, 39, le.Pos)
+	assert.Equal(t, le.Line, tok.Line)
+	assert.Equal(t, le.Column, tok.Column)
+	assert.Equal(t, le.Pos, tok.Pos)
+
+	l = NewSqlLexer("SELECT a FROM t")
+	for tok = l.NextToken(); tok.T != TokenEOF; tok = l.NextToken() {
+	}
+	assert.Equal(t, nil, l.Err())
+
+	// raw input in the message is not treated as a format
+	_, err = Tokenize("%d x")
+	assert.Equal(t, "un recognized keyword token:%d", err.(*LexError).Msg)
+}
+
+func TestLexStrictMode(t *testing.T) {
+	for _, tt := range []struct {
+		sql    string
+		column int
+	}{
+		{`SELECT a FROM t WHERE x = 1 )`, 28},
+		{`SELECT a FROM t WHERE x = )`, 26},
+	} {
+		// by default the stray ) is dropped
+		_, err := Tokenize(tt.sql)
+		assert.Equal(t, nil, err, tt.sql)
+
+		l := NewSqlLexer(tt.sql)
+		l.StrictMode = true
+		toks := l.AppendTokens(nil)
+		lerr, ok := l.Err().(*LexError)
+		assert.True(t, ok, "expected *LexError for %q got %v", tt.sql, err)
+		if ok {
+			assert.Equal(t, 1, lerr.Line, tt.sql)
+			assert.Equal(t, tt.column, lerr.Column, tt.sql)
+		}
+		assert.Equal(t, TokenError, toks[len(toks)-1].T, tt.sql)
+	}
+
+	// valid statements are unaffected
+	l := NewSqlLexer(`SELECT a, count(*) FROM t WHERE x IN (1, 2) AND (y > 3) GROUP BY a;`)
+	l.StrictMode = true
+	l.AppendTokens(nil)
+	assert.Equal(t, nil, l.Err())
+
+	// clauses out of order
+	for _, tt := range []struct {
+		sql string
+		msg string
+	}{
+		{`SELECT a FROM t ORDER BY x WHERE y = 1`, "WHERE must come before ORDER BY"},
+		{`SELECT a FROM t LIMIT 10 WHERE y = 1`, "WHERE must come before LIMIT"},
+		{`SELECT a FROM t WHERE y = 1 ORDER BY x GROUP BY a`, "GROUP BY must come before ORDER BY"},
+		{`SELECT a FROM t HAVING count(*) > 1 GROUP BY a`, "GROUP BY must come before HAVING"},
+	} {
+		l.Reset(tt.sql)
+		l.AppendTokens(nil)
+		lerr, ok := l.Err().(*LexError)
+		assert.True(t, ok, "expected *LexError for %q got %v", tt.sql, l.Err())
+		if ok {
+			assert.Equal(t, tt.msg, lerr.Msg, tt.sql)
+		}
+	}
+	// a sub-query has its own clauses
+	l.Reset(`SELECT a FROM t GROUP BY a HAVING a IN (SELECT b FROM u WHERE c = 1) ORDER BY a`)
+	l.AppendTokens(nil)
+	assert.Equal(t, nil, l.Err())
+}
+
+func TestLexUnterminatedValue(t *testing.T) {
+	for _, sql := range []string{
+		`SELECT a FROM t WHERE x = 'unterminated`,
+		`SELECT a FROM t WHERE x = "unterminated`,
+		`SELECT a FROM t WHERE x = 'it''s`,
+		`SELECT a FROM t WHERE x = 'escaped\'`,
+	} {
+		toks := lexTokens(sql)
+		tok := toks[len(toks)-1]
+		assert.Equal(t, TokenError, tok.T, "expected error for %q got %v", sql, tok)
+		assert.Equal(t, "reached end without finding end for quoted value", tok.V)
+		assert.Equal(t, len(sql), tok.Pos)
+	}
+
+	tok := token(`"name`, LexJsonIdentity)
+	assert.Equal(t, TokenError, tok.T, "%v", tok)
+	assert.Equal(t, 5, tok.Pos)
+}
+
+func TestLexDeepNesting(t *testing.T) {
+	nested := func(depth int) string {
+		return "SELECT a FROM t WHERE " + strings.Repeat("(", depth) + "a = 1" + strings.Repeat(")", depth)
+	}
+	countParens := func(toks []Token) (left, right int) {
+		for _, tok := range toks {
+			switch tok.T {
+			case TokenLeftParenthesis:
+				left++
+			case TokenRightParenthesis:
+				right++
+			}
+		}
+		return
+	}
+
+	toks, err := Tokenize(nested(200))
+	assert.Equal(t, nil, err)
+	left, right := countParens(toks)
+	assert.Equal(t, 200, left)
+	assert.Equal(t, 200, right)
+
+	// past the max depth is an error, not silently mis-lexed
+	l := NewSqlLexer(nested(5))
+	l.MaxDepth = 30
+	l.AppendTokens(nil)
+	assert.Equal(t, nil, l.Err())
+	l.Reset(nested(50))
+	toks = l.AppendTokens(nil)
+	err = l.Err()
+	assert.NotEqual(t, nil, err)
+	assert.True(t, strings.Contains(err.Error(), "nested too deeply"), "%v", err)
+	_, right = countParens(toks)
+	assert.Equal(t, 0, right)
+
+	// sub-queries share the depth of the statement they are in
+	subQueries := func(depth int) string {
+		return "SELECT a FROM t WHERE id IN (" + strings.Repeat("SELECT b FROM u WHERE id IN (", depth) +
+			"1" + strings.Repeat(")", depth+1)
+	}
+	toks, err = Tokenize(subQueries(20))
+	assert.Equal(t, nil, err)
+	left, right = countParens(toks)
+	assert.Equal(t, 21, left)
+	assert.Equal(t, 21, right)
+	_, err = Tokenize(subQueries(1500))
+	assert.NotEqual(t, nil, err)
+	assert.True(t, strings.Contains(err.Error(), "nested too deeply"), "%v", err)
+	l = NewSqlLexer(subQueries(20))
+	l.MaxDepth = 30
+	l.AppendTokens(nil)
+	assert.NotEqual(t, nil, l.Err())
+}
+
+func TestLexMaxInputLen(t *testing.T) {
+	// unterminated comments and strings would otherwise be scanned to the end
+	huge := "SELECT a FROM t /* " + strings.Repeat("x", 5000)
+
+	_, err := Tokenize(huge)
+	assert.NotEqual(t, nil, err)
+	assert.True(t, !strings.Contains(err.Error(), "too long"), "%v", err)
+
+	tokenize := func(sql string) ([]Token, error) {
+		l := NewSqlLexer(sql)
+		l.MaxInputLen = 1000
+		toks := l.AppendTokens(nil)
+		return toks[:len(toks)-1], l.Err()
+	}
+
+	toks, err := tokenize(huge)
+	assert.NotEqual(t, nil, err)
+	assert.True(t, strings.Contains(err.Error(), "too long"), "%v", err)
+	assert.Equal(t, 0, len(toks))
+
+	// nothing is lexed, the error is the first and only token
+	l := NewSqlLexer(`SELECT '` + strings.Repeat("y", 2000))
+	l.MaxInputLen = 1000
+	assert.Equal(t, TokenError, l.NextToken().T)
+	assert.Equal(t, TokenEOF, l.NextToken().T)
+
+	// the limit is kept across a Reset
+	l.Reset(huge)
+	assert.Equal(t, TokenError, l.NextToken().T)
+
+	// trailing whitespace is not counted
+	toks, err = tokenize("SELECT a FROM t" + strings.Repeat(" ", 2000))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 4, len(toks))
+
+	_, err = tokenize(`SELECT a FROM t WHERE b = 'short'`)
+	assert.Equal(t, nil, err)
 }
 
 func TestLexTSQL(t *testing.T) {
@@ -1044,6 +2111,52 @@ func TestLexAlter(t *testing.T) {
 			tv(TokenIdentity, "utf8"),
 			tv(TokenEOS, ";"),
 		})
+
+	verifyTokens(t, `ALTER TABLE users ADD COLUMN age INTEGER`,
+		[]Token{
+			tv(TokenAlter, "ALTER"),
+			tv(TokenTable, "TABLE"),
+			tv(TokenIdentity, "users"),
+			tv(TokenAdd, "ADD"),
+			tv(TokenColumn, "COLUMN"),
+			tv(TokenIdentity, "age"),
+			tv(TokenTypeInteger, "INTEGER"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `ALTER TABLE users DROP COLUMN age;`,
+		[]Token{
+			tv(TokenAlter, "ALTER"),
+			tv(TokenTable, "TABLE"),
+			tv(TokenIdentity, "users"),
+			tv(TokenDrop, "DROP"),
+			tv(TokenColumn, "COLUMN"),
+			tv(TokenIdentity, "age"),
+			tv(TokenEOS, ";"),
+		})
+	verifyTokens(t, `ALTER TABLE users MODIFY COLUMN name VARCHAR(100)`,
+		[]Token{
+			tv(TokenAlter, "ALTER"),
+			tv(TokenTable, "TABLE"),
+			tv(TokenIdentity, "users"),
+			tv(TokenModify, "MODIFY"),
+			tv(TokenColumn, "COLUMN"),
+			tv(TokenIdentity, "name"),
+			tv(TokenTypeVarChar, "VARCHAR"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "100"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+	// COLUMN is optional
+	verifyTokens(t, `ALTER TABLE users DROP age`,
+		[]Token{
+			tv(TokenAlter, "ALTER"),
+			tv(TokenTable, "TABLE"),
+			tv(TokenIdentity, "users"),
+			tv(TokenDrop, "DROP"),
+			tv(TokenIdentity, "age"),
+			tv(TokenEOF, ""),
+		})
 }
 
 func TestLexUpdate(t *testing.T) {
@@ -1228,6 +2341,125 @@ func TestLexInsert(t *testing.T) {
 			tv(TokenRightParenthesis, ")"),
 			tv(TokenEOS, ";"),
 		})
+
+	// quoted and bracketed column names
+	verifyTokens(t, "INSERT INTO logs ( `site id` ,[time], hits) VALUES (1, \"2004-08-09\", 15)",
+		[]Token{
+			tv(TokenInsert, "INSERT"),
+			tv(TokenInto, "INTO"),
+			tv(TokenTable, "logs"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "site id"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "time"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "hits"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenValues, "VALUES"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenValue, "2004-08-09"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "15"),
+			tv(TokenRightParenthesis, ")"),
+		})
+
+	// MySQL upsert, the assignments are lexed as an UPDATE SET
+	verifyTokens(t, `INSERT INTO logs (site_id, hits) VALUES (1, 15) ON DUPLICATE KEY UPDATE hits = hits + 1`,
+		[]Token{
+			tv(TokenInsert, "INSERT"),
+			tv(TokenInto, "INTO"),
+			tv(TokenTable, "logs"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "site_id"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "hits"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenValues, "VALUES"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "15"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenOn, "ON"),
+			tv(TokenDuplicate, "DUPLICATE"),
+			tv(TokenKey, "KEY"),
+			tv(TokenUpdate, "UPDATE"),
+			tv(TokenIdentity, "hits"),
+			tv(TokenEqual, "="),
+			tv(TokenIdentity, "hits"),
+			tv(TokenPlus, "+"),
+			tv(TokenInteger, "1"),
+			tv(TokenEOF, ""),
+		})
+	verifyTokens(t, `INSERT INTO t (a, b) VALUES (1, 2), (3, 4) on duplicate key update b = VALUES(b), a = 0`,
+		[]Token{
+			tv(TokenInsert, "INSERT"),
+			tv(TokenInto, "INTO"),
+			tv(TokenTable, "t"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "a"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "b"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenValues, "VALUES"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "2"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "3"),
+			tv(TokenComma, ","),
+			tv(TokenInteger, "4"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenOn, "on"),
+			tv(TokenDuplicate, "duplicate"),
+			tv(TokenKey, "key"),
+			tv(TokenUpdate, "update"),
+			tv(TokenIdentity, "b"),
+			tv(TokenEqual, "="),
+			tv(TokenUdfExpr, "VALUES"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "b"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenIdentity, "a"),
+			tv(TokenEqual, "="),
+			tv(TokenInteger, "0"),
+			tv(TokenEOF, ""),
+		})
+	// the suffix is optional
+	verifyTokens(t, `INSERT INTO t (a) VALUES (1), (2)`,
+		[]Token{
+			tv(TokenInsert, "INSERT"),
+			tv(TokenInto, "INTO"),
+			tv(TokenTable, "t"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenIdentity, "a"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenValues, "VALUES"),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "1"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenComma, ","),
+			tv(TokenLeftParenthesis, "("),
+			tv(TokenInteger, "2"),
+			tv(TokenRightParenthesis, ")"),
+			tv(TokenEOF, ""),
+		})
+
+	for _, sql := range []string{
+		`INSERT INTO users (name email) VALUES ("bob", "x")`,
+		`INSERT INTO users (name,,email) VALUES ("bob", "x")`,
+		`INSERT INTO users (name, "email") VALUES ("bob", "x")`,
+		`INSERT INTO users (name,`,
+	} {
+		_, err := Tokenize(sql)
+		assert.NotEqual(t, nil, err, sql)
+	}
 }
 
 func TestLexDelete(t *testing.T) {
@@ -1283,3 +2515,247 @@ func TestWithJson(t *testing.T) {
 			TokenRightBrace,
 		})
 }
+
+var benchQueries = func() []string {
+	sqls := []string{
+		`SELECT a, b, count(*) AS ct FROM users WHERE x > 1 AND name = "bob" GROUP BY a, b LIMIT 10`,
+		`SELECT u.name, o.total FROM users AS u INNER JOIN orders AS o ON u.id = o.user_id WHERE o.total > 10`,
+		`SELECT * FROM events WHERE user_id IN ("a","b","c") AND ts BETWEEN todate("2016-01-01") AND now()`,
+		`INSERT INTO mytable (id, str) VALUES (0, "a"),(1,"b")`,
+		`UPDATE users SET name = "bob", age = 22 WHERE id = 5`,
+	}
+	qs := make([]string, 10000)
+	for i := range qs {
+		qs[i] = sqls[i%len(sqls)]
+	}
+	return qs
+}()
+
+// Lex 10k statements
+func BenchmarkLexSql(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, sql := range benchQueries {
+			l := NewSqlLexer(sql)
+			for tok := l.NextToken(); tok.T != TokenEOF && tok.T != TokenError; tok = l.NextToken() {
+			}
+		}
+	}
+}
+
+// Each op is one NextToken call on a long statement, re-lexed whenever it
+// runs out, so ns/op is the cost of handing a token to the caller
+func BenchmarkLexNextToken(b *testing.B) {
+	var buf strings.Builder
+	buf.WriteString("SELECT ")
+	for i := 0; i < 500; i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "c%d + %d AS a%d", i, i, i)
+	}
+	buf.WriteString(" FROM t WHERE x > 1")
+	sql := buf.String()
+	l := NewSqlLexer(sql)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if tok := l.NextToken(); tok.T == TokenEOF || tok.T == TokenError {
+			l.Reset(sql)
+		}
+	}
+}
+
+// With Trace off the lexer must not log, nor format log messages, so
+// re-using a lexer and its token buffer should not allocate at all (lower
+// case keywords, as upper case ones are lower cased to match them).
+func TestLexReuseNoAllocs(t *testing.T) {
+	sql := `select name, count(*) as ct from users where score > 1.5 and x in (1,2) group by name limit 10`
+	l := NewSqlLexer(sql)
+	toks := l.AppendTokens(make([]Token, 0, 40))
+	allocs := testing.AllocsPerRun(10, func() {
+		l.Reset(sql)
+		toks = l.AppendTokens(toks[:0])
+	})
+	assert.Equal(t, 0.0, allocs, "expected no allocations with Trace off")
+}
+
+func BenchmarkLexTraceOff(b *testing.B) {
+	b.ReportAllocs()
+	sql := `select name, count(*) as ct from users where score > 1.5 and x in (1,2) group by name limit 10`
+	l := NewSqlLexer(sql)
+	toks := l.AppendTokens(make([]Token, 0, 40))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Reset(sql)
+		toks = l.AppendTokens(toks[:0])
+	}
+}
+
+// ~1MB multi-statement script
+var benchScript = func() string {
+	var buf strings.Builder
+	for buf.Len() < 1<<20 {
+		for _, sql := range benchQueries[:5] {
+			buf.WriteString(sql)
+			buf.WriteString(";\n")
+		}
+	}
+	return buf.String()
+}()
+
+// Lex a 1MB script with a new lexer per statement
+func BenchmarkLexSqlScript(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchScript)))
+	for i := 0; i < b.N; i++ {
+		l := NewSqlLexer(benchScript)
+		for {
+			tok := l.NextToken()
+			for ; tok.T != TokenEOF && tok.T != TokenEOS && tok.T != TokenError; tok = l.NextToken() {
+			}
+			sql, hasMore := l.Remainder()
+			if !hasMore || tok.T == TokenError {
+				break
+			}
+			l = NewSqlLexer(sql)
+		}
+	}
+}
+
+// Lex a 1MB script re-using one lexer and token buffer
+func BenchmarkLexSqlScriptReuse(b *testing.B) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchScript)))
+	toks := make([]Token, 0, 100)
+	for i := 0; i < b.N; i++ {
+		l := NewSqlLexer(benchScript)
+		for {
+			toks = l.AppendTokens(toks[:0])
+			sql, hasMore := l.Remainder()
+			if !hasMore || l.Err() != nil {
+				break
+			}
+			l.Reset(sql)
+		}
+	}
+}
+
+func TestLexerReset(t *testing.T) {
+	l := NewSqlLexer(`SELECT a FROM t WHERE x = "y"; SELECT b FROM t2`)
+	toks := l.AppendTokens(nil)
+	assert.Equal(t, TokenEOS, toks[len(toks)-1].T)
+	assert.Equal(t, 9, len(toks))
+
+	sql, hasMore := l.Remainder()
+	assert.True(t, hasMore)
+	l.Reset(sql)
+	toks = l.AppendTokens(toks[:0])
+	assert.Equal(t, 5, len(toks))
+	assert.Equal(t, "b", toks[1].V)
+	assert.Equal(t, TokenEOF, toks[4].T)
+
+	// a join must not carry its state past the end of statement
+	l.Reset(`SELECT u.name FROM users AS u INNER JOIN orders AS o ON u.id = o.uid; UPDATE t SET a = 1`)
+	toks = l.AppendTokens(toks[:0])
+	assert.Equal(t, TokenEOS, toks[len(toks)-1].T)
+	sql, _ = l.Remainder()
+	l.Reset(sql)
+	toks = l.AppendTokens(toks[:0])
+	assert.Equal(t, TokenUpdate, toks[0].T)
+	assert.Equal(t, TokenEOF, toks[len(toks)-1].T)
+
+	// a reset after an error starts clean
+	l.Reset(`SELECT a FROM t1 UNION DELETE FROM t2`)
+	toks = l.AppendTokens(toks[:0])
+	assert.Equal(t, TokenError, toks[len(toks)-1].T)
+	assert.NotEqual(t, nil, l.Err())
+	l.Reset(`SELECT a FROM t1`)
+	toks = l.AppendTokens(toks[:0])
+	assert.Equal(t, TokenEOF, toks[len(toks)-1].T)
+	assert.Equal(t, nil, l.Err())
+}
+
+func TestLexerStats(t *testing.T) {
+	l := NewSqlLexer(`SELECT a, count(*) AS ct FROM users WHERE x > 1 AND name = 'bob' GROUP BY a LIMIT 10`)
+	toks := l.AppendTokens(nil)
+	s := l.Stats()
+	assert.Equal(t, len(toks), s.Tokens)
+	assert.Equal(t, 6, s.Counts[TokenIdentity])
+	assert.Equal(t, 2, s.Counts[TokenInteger])
+	assert.Equal(t, 1, s.Counts[TokenUdfExpr])
+	assert.Equal(t, 1, s.Counts[TokenValue])
+	assert.Equal(t, 1, s.Counts[TokenGroupBy])
+	assert.Equal(t, 0, s.Counts[TokenOrderBy])
+	assert.Equal(t, false, s.Err)
+	flat := s.MaxDepth
+
+	// sub-queries nest deeper
+	l = NewSqlLexer(`SELECT a FROM t WHERE x IN (SELECT b FROM u WHERE c IN (SELECT d FROM v))`)
+	l.AppendTokens(toks[:0])
+	s = l.Stats()
+	assert.Equal(t, 3, s.Counts[TokenSelect])
+	assert.True(t, s.MaxDepth > flat, "depth %d should be more than %d", s.MaxDepth, flat)
+
+	// errors, and a reset starts over
+	l.Reset(`SELECT a FROM t WHERE b = 'unterminated`)
+	l.AppendTokens(toks[:0])
+	s = l.Stats()
+	assert.Equal(t, true, s.Err)
+	assert.Equal(t, 1, s.Counts[TokenError])
+	assert.Equal(t, 1, s.Counts[TokenSelect])
+	l.Reset(`SELECT a FROM t`)
+	toks = l.AppendTokens(toks[:0])
+	s = l.Stats()
+	assert.Equal(t, false, s.Err)
+	assert.Equal(t, len(toks), s.Tokens)
+}
+
+func TestLexerClone(t *testing.T) {
+	l := NewSqlLexer(`SELECT a, b AS c FROM t WHERE x IN (1, 2) AND (y = CASE WHEN z THEN 1 END)`)
+	for i := 0; i < 4; i++ {
+		l.NextToken()
+	}
+
+	// lex the rest of the statement on the clone
+	c := l.Clone()
+	ctoks := c.AppendTokens(nil)
+	assert.Equal(t, TokenEOF, ctoks[len(ctoks)-1].T)
+
+	// the original is still where it was, and lexes the same tokens
+	toks := l.AppendTokens(nil)
+	assert.Equal(t, len(ctoks), len(toks))
+	for i := range toks {
+		assert.Equal(t, ctoks[i], toks[i])
+	}
+	assert.Equal(t, TokenAs, toks[0].T)
+
+	// a reset of the clone does not touch the original's buffers
+	l = NewSqlLexer(`SELECT a FROM t`)
+	l.NextToken()
+	c = l.Clone()
+	c.Reset(`UPDATE t2 SET b = 1`)
+	assert.Equal(t, TokenUpdate, c.NextToken().T)
+	assert.Equal(t, "a", l.NextToken().V)
+}
+
+func TestTokenize(t *testing.T) {
+	toks, err := Tokenize(`SELECT a FROM t WHERE x = "y"; SELECT b FROM t2`)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 13, len(toks))
+	assert.Equal(t, TokenEOS, toks[8].T)
+	assert.Equal(t, TokenSelect, toks[9].T)
+	assert.Equal(t, "t2", toks[12].V)
+
+	toks, err = TokenizeDialect(`x = 1 AND y > 2`, LogicalExpressionDialect)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 7, len(toks))
+	assert.Equal(t, TokenLogicAnd, toks[3].T)
+
+	// the tokens up to the error are returned
+	toks, err = Tokenize(`SELECT a FROM t WHERE b = 'unterminated`)
+	assert.NotEqual(t, nil, err)
+	_, isLexErr := err.(*LexError)
+	assert.True(t, isLexErr)
+	assert.Equal(t, 7, len(toks))
+}
@@ -32,6 +32,28 @@ func (t Token) String() string {
 	return fmt.Sprintf(`Token{ %s Type:"%v" Line:%d Col:%d Q:%s Pos:%d}`,
 		t.V, t.T.String(), t.Line, t.Column, string(t.Quote), t.Pos)
 }
+
+// UnEscapedValue is the value of a TokenValueEscaped with the escaping
+// of its quote mark removed, other tokens return V as is.
+//
+//  'it''s'                 -> it's
+//  "she said ""hi"""       -> she said "hi"
+//  "she said \"hi\""       -> she said "hi"
+func (t Token) UnEscapedValue() string {
+	if t.T != TokenValueEscaped || t.Quote == 0 {
+		return t.V
+	}
+	quote := rune(t.Quote)
+	buf := make([]rune, 0, len(t.V))
+	rs := []rune(t.V)
+	for i := 0; i < len(rs); i++ {
+		if (rs[i] == quote || rs[i] == '\\') && i+1 < len(rs) && rs[i+1] == quote {
+			i++
+		}
+		buf = append(buf, rs[i])
+	}
+	return string(buf)
+}
 func (t Token) Err(l *Lexer) error { return t.ErrMsg(l, "") }
 func (t Token) ErrMsg(l *Lexer, msg string) error {
 	return l.ErrMsg(t, msg)
@@ -74,13 +96,15 @@ const (
 	TokenNewLine  TokenType = 6 // NewLine  = \n
 
 	// Comments
-	TokenComment           TokenType = 10 // Comment value string
-	TokenCommentML         TokenType = 11 // Comment MultiValue
-	TokenCommentStart      TokenType = 12 // /*
-	TokenCommentEnd        TokenType = 13 // */
-	TokenCommentSlashes    TokenType = 14 // Single Line comment:   // hello
-	TokenCommentSingleLine TokenType = 15 // Single Line comment:   -- hello
-	TokenCommentHash       TokenType = 16 // Single Line comment:  # hello
+	TokenComment             TokenType = 10 // Comment value string
+	TokenCommentML           TokenType = 11 // Comment MultiValue
+	TokenCommentStart        TokenType = 12 // /*
+	TokenCommentEnd          TokenType = 13 // */
+	TokenCommentSlashes      TokenType = 14 // Single Line comment:   // hello
+	TokenCommentSingleLine   TokenType = 15 // Single Line comment:   -- hello
+	TokenCommentHash         TokenType = 16 // Single Line comment:  # hello
+	TokenHint                TokenType = 17 // Optimizer hint comment:  /*+ hint */
+	TokenMysqlVersionComment TokenType = 18 // MySQL version gated comment:  /*!40101 sql */
 
 	// Misc
 	TokenComma        TokenType = 20 // ,
@@ -123,6 +147,32 @@ const (
 	TokenNull             TokenType = 88 // NULL
 	TokenContains         TokenType = 89 // CONTAINS
 	TokenIntersects       TokenType = 90 // INTERSECTS
+	TokenCase             TokenType = 91 // CASE
+	TokenWhen             TokenType = 92 // WHEN
+	TokenThen             TokenType = 93 // THEN
+	TokenElse             TokenType = 94 // ELSE
+	TokenEnd              TokenType = 95 // END
+	TokenEscape           TokenType = 96 // ESCAPE
+	TokenRegexp           TokenType = 97 // REGEXP
+	TokenNotRegexp        TokenType = 98 // !~
+	TokenILike            TokenType = 99 // ILIKE
+
+	// case insensitive regular expression match operators
+	TokenIRegexp    TokenType = 100 // ~*
+	TokenNotIRegexp TokenType = 101 // !~*
+
+	// postgres cast shorthand   price::text
+	TokenCastOp TokenType = 102 // ::
+
+	// bitwise operators
+	TokenBitAnd     TokenType = 103 // &
+	TokenBitOr      TokenType = 104 // |
+	TokenBitXor     TokenType = 105 // ^
+	TokenLeftShift  TokenType = 106 // <<
+	TokenRightShift TokenType = 107 // >>
+
+	// mysql null safe equality, NULL <=> NULL is true
+	TokenNullSafeEqual TokenType = 108 // <=>
 
 	// ql top-level keywords, these first keywords determine parser
 	TokenPrepare   TokenType = 200
@@ -141,6 +191,13 @@ const (
 	TokenReplace   TokenType = 213 // Insert/Replace are interchangeable on insert statements
 	TokenRollback  TokenType = 214
 	TokenCommit    TokenType = 215
+	TokenDrop      TokenType = 216
+	TokenTruncate  TokenType = 217
+	TokenBegin     TokenType = 218
+	TokenStart     TokenType = 219 // START TRANSACTION
+	TokenExecute   TokenType = 220 // EXECUTE a prepared statement
+
+	TokenDeallocate TokenType = 221 // DEALLOCATE PREPARE
 
 	// Other QL Keywords, These are clause-level keywords that mark separation between clauses
 	TokenFrom     TokenType = 300 // from
@@ -170,12 +227,46 @@ const (
 	TokenGlobal   TokenType = 324 // GLOBAL
 	TokenSession  TokenType = 325 // SESSION
 	TokenTables   TokenType = 326 // TABLES
+	TokenUnion    TokenType = 327 // UNION
+
+	// set operators joining select statements, as UNION does
+	TokenIntersect TokenType = 328 // INTERSECT
+	TokenExcept    TokenType = 329 // EXCEPT
+
+	// INSERT, UPDATE and DELETE ... RETURNING
+	TokenReturning TokenType = 330 // RETURNING
+
+	// SHOW DATABASES, SHOW COLUMNS
+	TokenDatabases TokenType = 331 // DATABASES
+	TokenColumns   TokenType = 332 // COLUMNS
+
+	// transaction and prepared statement keywords
+	TokenTransaction TokenType = 333 // TRANSACTION
+	TokenUsing       TokenType = 334 // USING
+
+	// window function keywords
+	TokenOver        TokenType = 335 // OVER
+	TokenPartitionBy TokenType = 336 // PARTITION BY
+
+	// quantified comparison keywords, x > ALL (...) is TokenAll
+	TokenAny  TokenType = 337 // ANY
+	TokenSome TokenType = 338 // SOME
+
+	// SELECT ... INTO OUTFILE 'path'
+	TokenOutfile TokenType = 339 // OUTFILE
+
+	// INSERT ... ON DUPLICATE KEY UPDATE
+	TokenDuplicate TokenType = 340 // DUPLICATE
+
+	// SELECT ... SKIP n, a synonym of OFFSET
+	TokenSkip TokenType = 341 // SKIP
 
 	// ddl major words
 	TokenTable          TokenType = 400 // table
 	TokenSource         TokenType = 401 // SOURCE
 	TokenView           TokenType = 402 // VIEW
 	TokenContinuousView TokenType = 403 // CONTINUOUSVIEW
+	TokenIndex          TokenType = 404 // INDEX
 
 	// ddl other
 	TokenChange       TokenType = 410 // change
@@ -191,13 +282,27 @@ const (
 	TokenForeign      TokenType = 420 // foreign
 	TokenReferences   TokenType = 421 // references
 	TokenEngine       TokenType = 422 // engine
+	TokenModify       TokenType = 423 // modify
+	TokenColumn       TokenType = 424 // column
 
 	// Other QL keywords
-	TokenSet  TokenType = 500 // set
-	TokenAs   TokenType = 501 // as
-	TokenAsc  TokenType = 502 // ascending
-	TokenDesc TokenType = 503 // descending
-	TokenUse  TokenType = 504 // use
+	TokenSet    TokenType = 500 // set
+	TokenAs     TokenType = 501 // as
+	TokenAsc    TokenType = 502 // ascending
+	TokenDesc   TokenType = 503 // descending
+	TokenUse    TokenType = 504 // use
+	TokenNulls  TokenType = 505 // nulls of ORDER BY x NULLS FIRST|LAST
+	TokenLast   TokenType = 506 // last
+	TokenRollup TokenType = 507 // rollup of GROUP BY x WITH ROLLUP
+	TokenTag    TokenType = 508 // tag of time-series GROUP BY tag(host)
+	TokenFetch  TokenType = 509 // fetch of OFFSET n ROWS FETCH FIRST n ROWS ONLY
+	TokenRows   TokenType = 510 // rows, or row
+	TokenOnly   TokenType = 511 // only
+	TokenNext   TokenType = 512 // next, as FIRST of FETCH NEXT n ROWS ONLY
+
+	// INTERVAL '7' DAY
+	TokenInterval     TokenType = 513 // interval
+	TokenIntervalUnit TokenType = 514 // the DAY, HOUR etc unit of an interval
 
 	// User defined function/expression
 	TokenUdfExpr TokenType = 550
@@ -208,6 +313,8 @@ const (
 	TokenValueEscaped TokenType = 602 // '' becomes ' inside the string, parser will need to replace the string
 	TokenRegex        TokenType = 603 // regex
 	TokenDuration     TokenType = 604 // 14d , 22w, 3y, 45ms, 45us, 24hr, 2h, 45m, 30s
+	TokenVariable     TokenType = 605 // session or system variable name in SET, autocommit, @var, @@session.var
+	TokenPlaceholder  TokenType = 606 // bind parameter ?, $1, :name; V is its ordinal or name, Quote the ? $ or :
 
 	// Data Type Definitions
 	TokenTypeDef     TokenType = 999
@@ -228,7 +335,9 @@ const (
 	TokenFloat     TokenType = 1002
 	TokenInteger   TokenType = 1003
 	TokenString    TokenType = 1004
-	TokenTime      TokenType = 1005
+	TokenTime      TokenType = 1005 // TIME '10:00:00'
+	TokenDate      TokenType = 1006 // DATE '2017-01-01'
+	TokenTimestamp TokenType = 1007 // TIMESTAMP '2017-01-01 10:00:00'
 
 	// Composite Data Types
 	TokenJson TokenType = 1010
@@ -247,6 +356,7 @@ var (
 	// list of token-name
 	TokenNameMap = map[TokenType]*TokenInfo{
 
+		TokenNil:      {Description: "nil"},
 		TokenEOF:      {Description: "EOF"},
 		TokenEOS:      {Description: ";"},
 		TokenEofOrEos: {Kw: "", Description: "; OR EOF"},
@@ -255,13 +365,15 @@ var (
 		TokenNewLine:  {Description: "New Line"},
 
 		// Comments
-		TokenComment:           {Description: "Comment"},
-		TokenCommentML:         {Description: "CommentMultiLine"},
-		TokenCommentStart:      {Description: "/*"},
-		TokenCommentEnd:        {Description: "*/"},
-		TokenCommentHash:       {Description: "#"},
-		TokenCommentSingleLine: {Description: "--"},
-		TokenCommentSlashes:    {Description: "//"},
+		TokenComment:             {Description: "Comment"},
+		TokenCommentML:           {Description: "CommentMultiLine"},
+		TokenCommentStart:        {Description: "/*"},
+		TokenCommentEnd:          {Description: "*/"},
+		TokenCommentHash:         {Description: "#"},
+		TokenCommentSingleLine:   {Description: "--"},
+		TokenCommentSlashes:      {Description: "//"},
+		TokenHint:                {Description: "Hint"},
+		TokenMysqlVersionComment: {Description: "MysqlVersionComment"},
 
 		// Misc
 		TokenComma:        {Description: ","},
@@ -300,6 +412,25 @@ var (
 		TokenNull:       {Kw: "null", Description: "NULL"},
 		TokenContains:   {Kw: "contains", Description: "contains"},
 		TokenIntersects: {Kw: "intersects", Description: "intersects"},
+		TokenCase:       {Kw: "case", Description: "case"},
+		TokenWhen:       {Kw: "when", Description: "when"},
+		TokenThen:       {Kw: "then", Description: "then"},
+		TokenElse:       {Kw: "else", Description: "else"},
+		TokenEnd:        {Kw: "end", Description: "end"},
+		TokenEscape:     {Kw: "escape", Description: "escape"},
+		TokenRegexp:     {Kw: "regexp", Description: "regexp"},
+		TokenNotRegexp:  {Kw: "!~", Description: "!~"},
+		TokenILike:      {Kw: "ilike", Description: "ILIKE"},
+		TokenIRegexp:    {Kw: "~*", Description: "~*"},
+		TokenNotIRegexp: {Kw: "!~*", Description: "!~*"},
+		TokenCastOp:     {Kw: "::", Description: "::"},
+		TokenBitAnd:     {Kw: "&", Description: "&"},
+		TokenBitOr:      {Kw: "|", Description: "|"},
+		TokenBitXor:     {Kw: "^", Description: "^"},
+		TokenLeftShift:  {Kw: "<<", Description: "<<"},
+		TokenRightShift: {Kw: ">>", Description: ">>"},
+
+		TokenNullSafeEqual: {Kw: "<=>", Description: "<=>"},
 
 		// Identity ish bools
 		TokenTrue:  {Kw: "true", Description: "True"},
@@ -329,6 +460,13 @@ var (
 		TokenReplace:   {Description: "replace"},
 		TokenRollback:  {Description: "rollback"},
 		TokenCommit:    {Description: "commit"},
+		TokenDrop:      {Description: "drop"},
+		TokenTruncate:  {Description: "truncate"},
+		TokenBegin:     {Description: "begin"},
+		TokenStart:     {Description: "start"},
+		TokenExecute:   {Description: "execute"},
+
+		TokenDeallocate: {Description: "deallocate"},
 
 		// Top Level dml ql clause keywords
 		TokenInto:    {Description: "into"},
@@ -359,12 +497,37 @@ var (
 		TokenGlobal:   {Description: "global"},
 		TokenSession:  {Description: "session"},
 		TokenTables:   {Description: "tables"},
+		TokenUnion:    {Description: "union"},
+
+		TokenIntersect: {Description: "intersect"},
+		TokenExcept:    {Description: "except"},
+
+		TokenReturning: {Description: "returning"},
+
+		TokenDatabases: {Description: "databases"},
+		TokenColumns:   {Description: "columns"},
+
+		TokenTransaction: {Description: "transaction"},
+		TokenUsing:       {Description: "using"},
+
+		TokenOver:        {Description: "over"},
+		TokenPartitionBy: {Description: "partition by"},
+
+		TokenAny:  {Description: "any"},
+		TokenSome: {Description: "some"},
+
+		TokenOutfile: {Description: "outfile"},
+
+		TokenDuplicate: {Description: "duplicate"},
+
+		TokenSkip: {Description: "skip"},
 
 		// ddl keywords
 		TokenTable:          {Description: "table"},
 		TokenSource:         {Description: "source"},
 		TokenView:           {Description: "view"},
 		TokenContinuousView: {Description: "continuousview"},
+		TokenIndex:          {Description: "index"},
 		// ddl other
 		TokenChange:       {Description: "change"},
 		TokenCharacterSet: {Description: "character set"},
@@ -379,13 +542,26 @@ var (
 		TokenForeign:      {Description: "foreign"},
 		TokenReferences:   {Description: "references"},
 		TokenEngine:       {Description: "engine"},
+		TokenModify:       {Description: "modify"},
+		TokenColumn:       {Description: "column"},
 
 		// QL Keywords, all lower-case
-		TokenSet:  {Description: "set"},
-		TokenAs:   {Description: "as"},
-		TokenAsc:  {Description: "asc"},
-		TokenDesc: {Description: "desc"},
-		TokenUse:  {Description: "use"},
+		TokenSet:    {Description: "set"},
+		TokenAs:     {Description: "as"},
+		TokenAsc:    {Description: "asc"},
+		TokenDesc:   {Description: "desc"},
+		TokenUse:    {Description: "use"},
+		TokenNulls:  {Description: "nulls"},
+		TokenLast:   {Description: "last"},
+		TokenRollup: {Description: "rollup"},
+		TokenTag:    {Description: "tag"},
+		TokenFetch:  {Description: "fetch"},
+		TokenRows:   {Description: "rows"},
+		TokenOnly:   {Description: "only"},
+		TokenNext:   {Description: "next"},
+
+		TokenInterval:     {Description: "interval"},
+		TokenIntervalUnit: {Description: "interval unit"},
 
 		// special value types
 		TokenIdentity:     {Description: "identity"},
@@ -393,6 +569,8 @@ var (
 		TokenValueEscaped: {Description: "value-escaped"},
 		TokenRegex:        {Description: "regex"},
 		TokenDuration:     {Description: "duration"},
+		TokenVariable:     {Description: "variable"},
+		TokenPlaceholder:  {Description: "placeholder"},
 
 		// Data TYPES:  ie type system
 		TokenTypeDef:     {Description: "TypeDef"}, // Generic DataType
@@ -408,11 +586,13 @@ var (
 		TokenTypeJson:    {Description: "JsonType"},
 
 		// VALUE TYPES:  ie literal values
-		TokenBool:    {Description: "BoolVal"},
-		TokenFloat:   {Description: "FloatVal"},
-		TokenInteger: {Description: "IntegerVal"},
-		TokenString:  {Description: "StringVal"},
-		TokenTime:    {Description: "TimeVal"},
+		TokenBool:      {Description: "BoolVal"},
+		TokenFloat:     {Description: "FloatVal"},
+		TokenInteger:   {Description: "IntegerVal"},
+		TokenString:    {Description: "StringVal"},
+		TokenTime:      {Description: "TimeVal"},
+		TokenDate:      {Description: "DateVal"},
+		TokenTimestamp: {Description: "TimestampVal"},
 
 		// Some other value Types
 		TokenValueType: {Description: "Value"}, // Generic DataType just stores in a value.Value
@@ -444,6 +624,8 @@ func LoadTokenInfo() {
 			ti.HasSpaces = true
 		}
 	}
+	// * is both TokenStar and TokenMultiply, as an operator it is multiply
+	TokenToOp[TokenMultiply.String()] = TokenMultiply
 }
 
 func TokenFromOp(op string) Token {
@@ -454,6 +636,17 @@ func TokenFromOp(op string) Token {
 	return Token{T: TokenNil, V: "nil"}
 }
 
+// TokenByName is the reverse of TokenType.String(), finding the TokenType
+// for a keyword or operator such as "select", "group by", ">=".  Keywords
+// are matched case insensitively.
+func TokenByName(name string) (TokenType, bool) {
+	if tt, ok := TokenToOp[name]; ok {
+		return tt, true
+	}
+	tt, ok := TokenToOp[strings.ToLower(name)]
+	return tt, ok
+}
+
 // convert to human readable string
 func (typ TokenType) String() string {
 	s, ok := TokenNameMap[typ]
@@ -485,3 +678,101 @@ func (typ TokenType) MultiWord() bool {
 	}
 	return false
 }
+
+// IsKeyword is true for the ql keywords, the statement, clause, ddl and
+// other keywords in the 200-549 range.  Operators that are spelled as
+// words such as AND, LIKE are operators, not keywords.
+func (typ TokenType) IsKeyword() bool {
+	return typ >= TokenPrepare && typ < TokenUdfExpr
+}
+
+// IsClauseKeyword is true for the keywords starting the clauses of a
+// select statement, which must be in the order
+// SELECT, FROM, WHERE, GROUP BY, HAVING, ORDER BY, LIMIT.
+func (typ TokenType) IsClauseKeyword() bool {
+	switch typ {
+	case TokenSelect, TokenFrom, TokenWhere, TokenGroupBy, TokenHaving, TokenOrderBy, TokenLimit:
+		return true
+	}
+	return false
+}
+
+// IsLiteral is true for tokens that carry a literal value, quoted strings,
+// numbers, durations, regex and the true, false, null words.
+func (typ TokenType) IsLiteral() bool {
+	switch typ {
+	case TokenTrue, TokenFalse, TokenNull:
+		return true
+	}
+	return (typ > TokenIdentity && typ <= TokenDuration) ||
+		(typ > TokenValueType && typ <= TokenMap)
+}
+
+// IsOperator is true for the arithmetic, logical, bitwise and comparison
+// operators.  Parentheses, the literal words, IF and the CASE keywords
+// share the operand range but are not operators.
+func (typ TokenType) IsOperator() bool {
+	switch typ {
+	case TokenMinus, TokenPlus, TokenPlusPlus, TokenPlusEquals, TokenDivide, TokenMultiply, TokenModulus,
+		TokenOr, TokenAnd, TokenLogicOr, TokenLogicAnd, TokenNegate, TokenCastOp,
+		TokenBitAnd, TokenBitOr, TokenBitXor, TokenLeftShift, TokenRightShift:
+		return true
+	}
+	return typ.IsComparison()
+}
+
+// IsComparison is true for the operators that compare two values
+// to produce a bool, such as =, >=, LIKE, IN.
+func (typ TokenType) IsComparison() bool {
+	switch typ {
+	case TokenEqual, TokenEqualEqual, TokenNE, TokenGE, TokenLE, TokenGT, TokenLT, TokenNullSafeEqual,
+		TokenBetween, TokenIN, TokenLike, TokenILike, TokenIs, TokenContains,
+		TokenIntersects, TokenRegexp, TokenNotRegexp, TokenIRegexp, TokenNotIRegexp:
+		return true
+	}
+	return false
+}
+
+// Operator precedence levels returned by Precedence, a higher level binds
+// tighter, so a + b * c is a + (b * c).
+const (
+	PrecedenceNone           = 0
+	PrecedenceOr             = 1 // OR ||
+	PrecedenceAnd            = 2 // AND &&
+	PrecedenceNot            = 3 // NOT
+	PrecedenceComparison     = 4 // = != < LIKE IN BETWEEN IS ...
+	PrecedenceBitwise        = 5 // | & ^ << >>
+	PrecedenceAdditive       = 6 // + -
+	PrecedenceMultiplicative = 7 // * / %
+	PrecedenceCast           = 8 // ::
+)
+
+// Precedence is the standard sql precedence of an operator token, for
+// parsers building expression trees from the lexers flat token stream.
+// Tokens that are not operators are PrecedenceNone.
+//
+//    http://dev.mysql.com/doc/refman/5.7/en/operator-precedence.html
+//    http://www.postgresql.org/docs/9.4/static/sql-syntax-lexical.html#SQL-PRECEDENCE
+//
+func Precedence(t TokenType) int {
+	switch t {
+	case TokenLogicOr, TokenOr:
+		return PrecedenceOr
+	case TokenLogicAnd, TokenAnd:
+		return PrecedenceAnd
+	case TokenNegate:
+		return PrecedenceNot
+	case TokenBitOr, TokenBitAnd, TokenBitXor, TokenLeftShift, TokenRightShift:
+		return PrecedenceBitwise
+	case TokenPlus, TokenMinus:
+		return PrecedenceAdditive
+	case TokenMultiply, TokenDivide, TokenModulus:
+		return PrecedenceMultiplicative
+	case TokenCastOp:
+		return PrecedenceCast
+	}
+	if t.IsComparison() {
+		return PrecedenceComparison
+	}
+	return PrecedenceNone
+}
@@ -0,0 +1,150 @@
+package lex
+
+import (
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenTypeClassification(t *testing.T) {
+	tests := []struct {
+		typ        TokenType
+		keyword    bool
+		literal    bool
+		operator   bool
+		comparison bool
+	}{
+		{TokenSelect, true, false, false, false},
+		{TokenWhere, true, false, false, false},
+		{TokenTable, true, false, false, false},
+		{TokenAs, true, false, false, false},
+		{TokenUdfExpr, false, false, false, false},
+		{TokenIdentity, false, false, false, false},
+		{TokenValue, false, true, false, false},
+		{TokenValueEscaped, false, true, false, false},
+		{TokenDuration, false, true, false, false},
+		{TokenInteger, false, true, false, false},
+		{TokenFloat, false, true, false, false},
+		{TokenBool, false, true, false, false},
+		{TokenDate, false, true, false, false},
+		{TokenNull, false, true, false, false},
+		{TokenTrue, false, true, false, false},
+		{TokenPlus, false, false, true, false},
+		{TokenMultiply, false, false, true, false},
+		{TokenLogicAnd, false, false, true, false},
+		{TokenNegate, false, false, true, false},
+		{TokenEqual, false, false, true, true},
+		{TokenGE, false, false, true, true},
+		{TokenLike, false, false, true, true},
+		{TokenIN, false, false, true, true},
+		{TokenNotIRegexp, false, false, true, true},
+		{TokenCastOp, false, false, true, false},
+		{TokenBitAnd, false, false, true, false},
+		{TokenRightShift, false, false, true, false},
+		{TokenNullSafeEqual, false, false, true, true},
+		{TokenBetween, false, false, true, true},
+		{TokenIs, false, false, true, true},
+		{TokenLeftParenthesis, false, false, false, false},
+		{TokenIf, false, false, false, false},
+		{TokenEscape, false, false, false, false},
+		{TokenAny, true, false, false, false},
+		{TokenCase, false, false, false, false},
+		{TokenComma, false, false, false, false},
+		{TokenEOF, false, false, false, false},
+		{TokenTypeDef, false, false, false, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.keyword, tt.typ.IsKeyword(), "keyword %v", tt.typ)
+		assert.Equal(t, tt.literal, tt.typ.IsLiteral(), "literal %v", tt.typ)
+		assert.Equal(t, tt.operator, tt.typ.IsOperator(), "operator %v", tt.typ)
+		assert.Equal(t, tt.comparison, tt.typ.IsComparison(), "comparison %v", tt.typ)
+	}
+	for _, typ := range []TokenType{TokenSelect, TokenFrom, TokenWhere, TokenGroupBy, TokenHaving, TokenOrderBy, TokenLimit} {
+		assert.True(t, typ.IsClauseKeyword(), "clause keyword %v", typ)
+	}
+	for _, typ := range []TokenType{TokenTable, TokenAs, TokenOffset, TokenInto, TokenIdentity} {
+		assert.True(t, !typ.IsClauseKeyword(), "clause keyword %v", typ)
+	}
+}
+
+func TestTokenNameMapComplete(t *testing.T) {
+	// find every TokenType constant declared in token.go
+	f, err := parser.ParseFile(gotoken.NewFileSet(), "token.go", nil, 0)
+	assert.Equal(t, nil, err)
+	names := 0
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != gotoken.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if id, ok := vs.Type.(*ast.Ident); !ok || id.Name != "TokenType" {
+				continue
+			}
+			n, err := strconv.Atoi(vs.Values[0].(*ast.BasicLit).Value)
+			assert.Equal(t, nil, err)
+			names++
+			typ := TokenType(n)
+			assert.NotEqual(t, "not implemented", typ.String(), "%s has no name", vs.Names[0].Name)
+
+			tt, ok := TokenByName(typ.String())
+			assert.True(t, ok, "%s not found by name %q", vs.Names[0].Name, typ.String())
+			if typ != TokenStar {
+				assert.Equal(t, typ, tt, "%s found by name %q", vs.Names[0].Name, typ.String())
+			}
+		}
+	}
+	assert.True(t, names > 100, "expected to find the TokenType constants")
+
+	tt, ok := TokenByName("SELECT")
+	assert.True(t, ok)
+	assert.Equal(t, TokenSelect, tt)
+	tt, ok = TokenByName("group by")
+	assert.True(t, ok)
+	assert.Equal(t, TokenGroupBy, tt)
+	tt, _ = TokenByName("*")
+	assert.Equal(t, TokenMultiply, tt)
+	_, ok = TokenByName("not a token")
+	assert.Equal(t, false, ok)
+}
+
+func TestTokenPrecedence(t *testing.T) {
+	// OR < AND < NOT < comparison < bitwise < additive < multiplicative < cast
+	order := [][]TokenType{
+		{TokenLogicOr, TokenOr},
+		{TokenLogicAnd, TokenAnd},
+		{TokenNegate},
+		{TokenEqual, TokenNE, TokenLT, TokenGE, TokenNullSafeEqual, TokenLike, TokenIN, TokenBetween, TokenIs},
+		{TokenBitOr, TokenBitAnd, TokenBitXor, TokenLeftShift, TokenRightShift},
+		{TokenPlus, TokenMinus},
+		{TokenMultiply, TokenDivide, TokenModulus},
+		{TokenCastOp},
+	}
+	for i, level := range order {
+		for _, typ := range level {
+			// the same as the rest of its level
+			assert.Equal(t, Precedence(level[0]), Precedence(typ), "%v", typ)
+			if i > 0 {
+				lower := order[i-1][0]
+				assert.True(t, Precedence(typ) > Precedence(lower), "%v binds tighter than %v", typ, lower)
+			}
+		}
+	}
+
+	// a + b * c
+	assert.True(t, Precedence(TokenMultiply) > Precedence(TokenPlus))
+	// a = 1 OR b = 2 AND c = 3
+	assert.True(t, Precedence(TokenLogicAnd) > Precedence(TokenLogicOr))
+	assert.True(t, Precedence(TokenEqual) > Precedence(TokenLogicAnd))
+	// -a::int * 2
+	assert.True(t, Precedence(TokenCastOp) > Precedence(TokenMultiply))
+
+	for _, typ := range []TokenType{TokenIdentity, TokenInteger, TokenComma, TokenLeftParenthesis, TokenSelect, TokenStar} {
+		assert.Equal(t, PrecedenceNone, Precedence(typ), "%v", typ)
+	}
+}
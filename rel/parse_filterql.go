@@ -209,7 +209,8 @@ func (m *FilterQLParser) initialComment() string {
 		switch m.Cur().T {
 		case lex.TokenComment, lex.TokenCommentML:
 			comment += m.Cur().V
-		case lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd, lex.TokenCommentSingleLine, lex.TokenCommentSlashes:
+		case lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd, lex.TokenCommentSingleLine, lex.TokenCommentSlashes,
+			lex.TokenHint, lex.TokenMysqlVersionComment:
 			// skip, currently ignore these
 		default:
 			// first non-comment token
@@ -240,7 +241,8 @@ func (m *FilterQLParser) discardCommentsNewLines() {
 			m.Next()
 		case lex.TokenComment, lex.TokenCommentML,
 			lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd,
-			lex.TokenCommentSingleLine, lex.TokenCommentSlashes:
+			lex.TokenCommentSingleLine, lex.TokenCommentSlashes,
+			lex.TokenHint, lex.TokenMysqlVersionComment:
 			// skip, currently ignore these
 			m.Next()
 		default:
@@ -256,7 +258,8 @@ func (m *FilterQLParser) discardComments() {
 		switch m.Cur().T {
 		case lex.TokenComment, lex.TokenCommentML,
 			lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd,
-			lex.TokenCommentSingleLine, lex.TokenCommentSlashes:
+			lex.TokenCommentSingleLine, lex.TokenCommentSlashes,
+			lex.TokenHint, lex.TokenMysqlVersionComment:
 			// skip, currently ignore these
 			m.Next()
 		default:
@@ -72,7 +72,7 @@ func ParseSqlStatements(sqlQuery string) ([]SqlStatement, error) {
 		if !hasMore {
 			break
 		}
-		l = lex.NewSqlLexer(sqlRemaining)
+		l.Reset(sqlRemaining)
 		m = Sqlbridge{l: l, SqlTokenPager: NewSqlTokenPager(l)}
 	}
 	return stmts, nil
@@ -113,7 +113,7 @@ func (m *Sqlbridge) parse() (SqlStatement, error) {
 		return m.parseDescribe()
 	case lex.TokenSet, lex.TokenUse:
 		return m.parseCommand()
-	case lex.TokenRollback, lex.TokenCommit:
+	case lex.TokenRollback, lex.TokenCommit, lex.TokenBegin, lex.TokenStart:
 		return m.parseTransaction()
 	case lex.TokenCreate:
 		return m.parseCreate()
@@ -131,7 +131,8 @@ func (m *Sqlbridge) initialComment() string {
 		switch m.Cur().T {
 		case lex.TokenComment, lex.TokenCommentML:
 			comment += m.Cur().V
-		case lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd, lex.TokenCommentSingleLine, lex.TokenCommentSlashes:
+		case lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd, lex.TokenCommentSingleLine, lex.TokenCommentSlashes,
+			lex.TokenHint, lex.TokenMysqlVersionComment:
 			// skip, currently ignore these
 		default:
 			// first non-comment token
@@ -148,7 +149,8 @@ func discardComments(m expr.TokenPager) {
 		switch m.Cur().T {
 		case lex.TokenComment, lex.TokenCommentML,
 			lex.TokenCommentStart, lex.TokenCommentHash, lex.TokenCommentEnd,
-			lex.TokenCommentSingleLine, lex.TokenCommentSlashes:
+			lex.TokenCommentSingleLine, lex.TokenCommentSlashes,
+			lex.TokenHint, lex.TokenMysqlVersionComment:
 			// discard
 			m.Next()
 		default:
@@ -165,6 +167,7 @@ func (m *Sqlbridge) parseSqlSelect() (*SqlSelect, error) {
 	req := NewSqlSelect()
 	req.Raw = m.l.RawInput()
 	m.Next() // Consume Select?
+	discardComments(m)
 
 	// Optional DISTINCT keyword always immediately after SELECT KW
 	if m.Cur().T == lex.TokenDistinct {
@@ -760,9 +763,12 @@ func (m *Sqlbridge) parseCreate() (*SqlCreate, error) {
 
 func (m *Sqlbridge) parseTransaction() (*SqlCommand, error) {
 
-	// rollback, commit
+	// rollback, commit, begin, start transaction
 	req := &SqlCommand{Columns: make(CommandColumns, 0)}
-	req.kw = m.Next().T // rollback, commit
+	req.kw = m.Next().T // rollback, commit, begin, start
+	if m.Cur().T == lex.TokenTransaction {
+		m.Next()
+	}
 
 	return req, nil
 }
@@ -771,10 +777,11 @@ func parseColumns(m expr.TokenPager, fr expr.FuncResolver, stmt ColumnsStatement
 
 	var col *Column
 
-	discardComments(m)
-
 	for {
 
+		//  SELECT x, /* note */ y FROM t
+		discardComments(m)
+
 		//u.Debug(m.Cur())
 		switch m.Cur().T {
 		case lex.TokenStar, lex.TokenMultiply:
@@ -876,6 +883,9 @@ func parseColumns(m expr.TokenPager, fr expr.FuncResolver, stmt ColumnsStatement
 		case lex.TokenCommentSingleLine:
 			m.Next()
 			col.Comment = m.Cur().V
+		case lex.TokenCommentML, lex.TokenCommentHash, lex.TokenCommentSlashes:
+			//  SELECT x /* note */ FROM t
+			continue
 		case lex.TokenRightParenthesis:
 			// loop on my friend
 		case lex.TokenComma:
@@ -1053,6 +1063,7 @@ func (m *Sqlbridge) parseSources(req *SqlSelect) error {
 	}
 
 	m.Next() // page forward off of From
+	discardComments(m)
 	//u.Debugf("found from?  %v", m.Cur())
 
 	if m.Cur().T == lex.TokenIdentity {
@@ -1063,6 +1074,9 @@ func (m *Sqlbridge) parseSources(req *SqlSelect) error {
 
 	for {
 
+		//  FROM t /* note */ WHERE
+		discardComments(m)
+
 		src := &SqlSource{}
 		//u.Debugf("parseSources %v", m.Cur())
 		switch m.Cur().T {
@@ -1078,18 +1092,20 @@ func (m *Sqlbridge) parseSources(req *SqlSelect) error {
 			if m.Cur().T == lex.TokenRightParenthesis {
 				m.Next()
 			}
-		case lex.TokenLeft, lex.TokenRight, lex.TokenInner, lex.TokenOuter, lex.TokenJoin:
+		case lex.TokenLeft, lex.TokenRight, lex.TokenFull, lex.TokenInner, lex.TokenOuter,
+			lex.TokenCross, lex.TokenJoin:
 			// JOIN
 			if err := m.parseSourceJoin(src); err != nil {
 				return err
 			}
 		case lex.TokenEOF, lex.TokenEOS, lex.TokenWhere, lex.TokenGroupBy, lex.TokenLimit,
-			lex.TokenOffset, lex.TokenWith, lex.TokenAlias, lex.TokenOrderBy:
+			lex.TokenOffset, lex.TokenSkip, lex.TokenWith, lex.TokenAlias, lex.TokenOrderBy:
 			return nil
 		default:
 			return m.ErrMsg("unexpected token")
 		}
 
+		discardComments(m)
 		switch m.Cur().T {
 		case lex.TokenAs:
 			m.Next() // Skip over As, we don't need it
@@ -1100,6 +1116,7 @@ func (m *Sqlbridge) parseSources(req *SqlSelect) error {
 			src.Alias = m.Cur().V
 			m.Next()
 		}
+		discardComments(m)
 		if m.Cur().T == lex.TokenOn {
 			src.Op = m.Cur().T
 			m.Next()
@@ -1142,9 +1159,13 @@ func (m *Sqlbridge) parseSourceTable(req *SqlSelect) error {
 	src := SqlSource{}
 	req.From = append(req.From, &src)
 	src.Schema, src.Name, _ = expr.LeftRight(m.Next().V)
-	if m.Cur().T == lex.TokenAs {
+	switch m.Cur().T {
+	case lex.TokenAs:
 		m.Next() // Skip over "AS", we don't need it
 		src.Alias = m.Next().V
+	case lex.TokenIdentity:
+		// FROM users u
+		src.Alias = m.Next().V
 	}
 	return nil
 }
@@ -1152,14 +1173,14 @@ func (m *Sqlbridge) parseSourceTable(req *SqlSelect) error {
 func (m *Sqlbridge) parseSourceJoin(src *SqlSource) error {
 
 	switch m.Cur().T {
-	case lex.TokenLeft, lex.TokenRight:
+	case lex.TokenLeft, lex.TokenRight, lex.TokenFull:
 		src.LeftOrRight = m.Cur().T
 		m.Next()
 	}
 
-	// Optional Inner/Outer
+	// Optional Inner/Outer/Cross
 	switch m.Cur().T {
-	case lex.TokenInner, lex.TokenOuter:
+	case lex.TokenInner, lex.TokenOuter, lex.TokenCross:
 		src.JoinType = m.Cur().T
 		m.Next()
 	}
@@ -1194,7 +1215,8 @@ func (m *Sqlbridge) parseInto(req *SqlSelect) error {
 	}
 	m.Next() // Consume Into token
 
-	if m.Cur().T != lex.TokenTable {
+	//  SELECT a INTO @var FROM t
+	if m.Cur().T != lex.TokenTable && m.Cur().T != lex.TokenVariable {
 		return m.ErrMsg("expected table")
 	}
 	req.Into = &SqlInto{Table: m.Cur().V}
@@ -1251,6 +1273,7 @@ func (m *Sqlbridge) parseWhere() (*SqlWhere, error) {
 	}
 
 	m.Next() // Consume the Where
+	discardComments(m)
 	//u.Debugf("cur: %v peek=%v", m.Cur(), m.Peek())
 
 	where := SqlWhere{}
@@ -1374,6 +1397,12 @@ func (m *Sqlbridge) parseGroupBy(req *SqlSelect) (err error) {
 
 			// This indicates we have come to the End of the columns
 			req.GroupBy = append(req.GroupBy, col)
+			if m.Cur().T == lex.TokenWith && m.Peek().T == lex.TokenRollup {
+				//  GROUP BY a WITH ROLLUP
+				m.Next()
+				m.Next()
+				req.Rollup = true
+			}
 			return nil
 		case lex.TokenIf:
 			// If guard
@@ -1510,7 +1539,7 @@ func (m *Sqlbridge) parseCommandColumns(req *SqlCommand) (err error) {
 
 		//u.Debugf("command col? %v", m.Cur())
 		switch m.Cur().T {
-		case lex.TokenIdentity:
+		case lex.TokenVariable, lex.TokenIdentity:
 
 			col = &CommandColumn{Name: m.Cur().V}
 			exprNode, err := expr.ParseExprWithFuncs(m, m.funcs)
@@ -1906,8 +1935,8 @@ func (m *Sqlbridge) parseLimit(req *SqlSelect) error {
 		}
 		req.Offset = req.Limit
 		req.Limit = iv
-	case lex.TokenOffset:
-		m.Next() // consume "OFFSET"
+	case lex.TokenOffset, lex.TokenSkip:
+		m.Next() // consume "OFFSET" or its synonym "SKIP"
 		if m.Cur().T != lex.TokenInteger {
 			return m.ErrMsg("Offset must be an integer")
 		}
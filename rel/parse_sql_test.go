@@ -159,6 +159,15 @@ func TestSqlParseOnly(t *testing.T) {
 		INNER JOIN orders AS t3
 			ON t3.id = t2.fake_id;`)
 
+	parseSqlTest(t, `SELECT u.name FROM users u WHERE u.id = 1`)
+
+	parseSqlTest(t, `
+		SELECT 
+			u.name, o.total
+		FROM users u
+		LEFT OUTER JOIN orders o ON u.id = o.user_id
+		CROSS JOIN regions`)
+
 	// TODO:
 	//parseSqlTest(t, `INSERT INTO events (id,event_date,event) SELECT id,last_logon,"last_logon" FROM users;`)
 	// parseSqlTest(t, `REPLACE INTO tbl_3 (id,lastname) SELECT id,lastname FROM tbl_1;`)
@@ -293,6 +302,13 @@ func TestSqlParseAstCheck(t *testing.T) {
 	assert.True(t, sel.Limit == 100, "want limit = 100 but have %v", sel.Limit)
 	assert.True(t, sel.Offset == 0, "want offset = 0 but have %v", sel.Offset)
 
+	sql = "select name from `github_public` limit 100 skip 20;"
+	req, err = rel.ParseSql(sql)
+	assert.True(t, err == nil && req != nil, "Must parse: %s  \n\t%v", sql, err)
+	sel = req.(*rel.SqlSelect)
+	assert.True(t, sel.Limit == 100, "want limit = 100 but have %v", sel.Limit)
+	assert.True(t, sel.Offset == 20, "want offset = 20 but have %v", sel.Offset)
+
 	sql = "select `actor.id`, `actor.login` from github_watch where `actor.id` < 1000"
 	req, err = rel.ParseSql(sql)
 	assert.True(t, err == nil && req != nil, "Must parse: %s  \n\t%v", sql, err)
@@ -546,6 +562,108 @@ func TestSqlMultiStatement(t *testing.T) {
 	assert.True(t, ok, "wanted *SqlUpdate but got %T", stmts[1])
 	assert.True(t, sel.From[0].Name == "accounts", "has accounts: %v", sel.From[0])
 	assert.True(t, len(sel.Columns) == 2, "want 2 cols has %v", len(sel.Columns))
+
+	// a script of selects, each one a walkable ast
+	sql = `SELECT a, count(*) AS ct FROM t1 WHERE x > 1 GROUP BY a;
+	SELECT u.name FROM users AS u INNER JOIN orders AS o ON u.id = o.user_id;
+	SELECT c FROM t3 LIMIT 5`
+	stmts, err = rel.ParseSqlStatements(sql)
+	assert.True(t, err == nil, "Must parse: %s  \n\t%v", sql, err)
+	assert.True(t, len(stmts) == 3, "want 3 statements has %d", len(stmts))
+
+	sel = stmts[0].(*rel.SqlSelect)
+	assert.True(t, len(sel.Columns) == 2, "want 2 cols has %v", len(sel.Columns))
+	assert.True(t, sel.Columns[1].As == "ct", "want ct has %v", sel.Columns[1].As)
+	assert.True(t, sel.From[0].Name == "t1", "has t1: %v", sel.From[0])
+	assert.True(t, sel.Where != nil && sel.Where.Expr != nil, "has where")
+	assert.True(t, len(sel.GroupBy) == 1, "want 1 group by has %v", len(sel.GroupBy))
+
+	sel = stmts[1].(*rel.SqlSelect)
+	assert.True(t, len(sel.From) == 2, "want 2 sources has %v", len(sel.From))
+	assert.True(t, sel.From[1].Name == "orders", "has orders: %v", sel.From[1])
+
+	sel = stmts[2].(*rel.SqlSelect)
+	assert.True(t, sel.From[0].Name == "t3", "has t3: %v", sel.From[0])
+	assert.True(t, sel.Limit == 5, "want limit 5 has %v", sel.Limit)
+}
+
+func TestSqlSetOperatorInSubQuery(t *testing.T) {
+	t.Parallel()
+	// set operators are lexed but not yet parsed, these used to never return
+	rel.ParseSql(`SELECT a FROM t WHERE a IN (SELECT b FROM u) UNION SELECT c FROM v`)
+	parseSqlError(t, `SELECT a FROM t WHERE a IN (SELECT b FROM u UNION SELECT c FROM v)`)
+	parseSqlError(t, `SELECT a FROM t WHERE EXISTS (SELECT b FROM u INTERSECT SELECT c FROM v)`)
+	parseSqlError(t, `SELECT a FROM t WHERE a > ALL (SELECT b FROM u EXCEPT SELECT c FROM v)`)
+}
+
+func TestSqlAggregateQuantifier(t *testing.T) {
+	t.Parallel()
+	sql := `SELECT count(DISTINCT user_id), sum(ALL x) FROM events`
+	req, err := rel.ParseSql(sql)
+	assert.Equal(t, nil, err)
+	sel, ok := req.(*rel.SqlSelect)
+	assert.True(t, ok, "is SqlSelect: %T", req)
+
+	// the distinct args are wrapped, same as count(distinct(user_id))
+	fn, ok := sel.Columns[0].Expr.(*expr.FuncNode)
+	assert.True(t, ok, "%T", sel.Columns[0].Expr)
+	assert.Equal(t, 1, len(fn.Args))
+	assert.Equal(t, "DISTINCT(user_id)", fn.Args[0].String())
+	assert.Equal(t, "sum(x)", sel.Columns[1].Expr.String())
+	assert.Equal(t, "SELECT count(DISTINCT(user_id)), sum(x) FROM events", sel.String())
+
+	parseSqlTest(t, `SELECT count(distinct a, b) AS ct FROM t GROUP BY c`)
+}
+
+func TestSqlSelectIntoVariable(t *testing.T) {
+	t.Parallel()
+	req, err := rel.ParseSql(`SELECT a INTO @var FROM t`)
+	assert.Equal(t, nil, err)
+	sel, ok := req.(*rel.SqlSelect)
+	assert.True(t, ok, "is SqlSelect: %T", req)
+	assert.NotEqual(t, nil, sel.Into)
+	assert.Equal(t, "@var", sel.Into.Table)
+	assert.Equal(t, "SELECT a INTO @var FROM t", sel.String())
+}
+
+func TestSqlGroupByRollup(t *testing.T) {
+	t.Parallel()
+	sql := `SELECT a, b, count(*) AS ct FROM t GROUP BY a, b WITH ROLLUP HAVING ct > 1 ORDER BY a`
+	req, err := rel.ParseSql(sql)
+	assert.Equal(t, nil, err)
+	sel, ok := req.(*rel.SqlSelect)
+	assert.True(t, ok, "is SqlSelect: %T", req)
+	assert.Equal(t, 2, len(sel.GroupBy))
+	assert.Equal(t, true, sel.Rollup)
+	assert.NotEqual(t, nil, sel.Having)
+	assert.Equal(t, 1, len(sel.OrderBy))
+	assert.Equal(t, sql, sel.String())
+
+	parseSqlTest(t, `SELECT a FROM t GROUP BY a WITH ROLLUP`)
+	// still the with properties, not a rollup
+	req, err = rel.ParseSql(`SELECT a FROM t GROUP BY a WITH distributed = true`)
+	assert.Equal(t, nil, err)
+	sel = req.(*rel.SqlSelect)
+	assert.Equal(t, false, sel.Rollup)
+	assert.Equal(t, true, sel.With.Bool("distributed"))
+}
+
+func TestSqlComments(t *testing.T) {
+	t.Parallel()
+	// comments between any of the tokens are skipped
+	for sql, expect := range map[string]string{
+		`SELECT x /* note */ FROM t`:                             `SELECT x FROM t`,
+		`SELECT x, /* note */ y FROM t WHERE a = /* one */ 1`:    `SELECT x, y FROM t WHERE a = 1`,
+		`SELECT x FROM t /* c */ WHERE a = 1 /* d */ AND b = 2`:  `SELECT x FROM t WHERE a = 1 AND b = 2`,
+		`SELECT x FROM t AS u /* c */ INNER JOIN v ON u.a = v.a`: "SELECT x FROM t AS u\n\tINNER JOIN v ON u.a = v.a",
+	} {
+		req, err := rel.ParseSql(sql)
+		assert.Equal(t, nil, err, sql)
+		if err == nil {
+			assert.Equal(t, expect, req.String())
+		}
+		parseSqlTest(t, sql)
+	}
 }
 
 func TestSqlUpdate(t *testing.T) {
@@ -93,6 +93,7 @@ type (
 		Where     *SqlWhere    // Expr Node, or *SqlSelect
 		Having    expr.Node    // Filter results
 		GroupBy   Columns
+		Rollup    bool // GROUP BY ... WITH ROLLUP
 		OrderBy   Columns
 		Limit     int
 		Offset    int
@@ -124,8 +125,8 @@ type (
 		Alias       string             // From name aliased
 		Schema      string             //  FROM `schema`.`table`
 		Op          lex.TokenType      // In, =, ON
-		LeftOrRight lex.TokenType      // Left, Right
-		JoinType    lex.TokenType      // INNER, OUTER
+		LeftOrRight lex.TokenType      // Left, Right, Full
+		JoinType    lex.TokenType      // INNER, OUTER, CROSS
 		JoinExpr    expr.Node          // Join expression       x.y = q.y
 		SubQuery    *SqlSelect         // optional, Join/SubSelect statement
 
@@ -934,6 +935,7 @@ func sqlSelectToPbDepth(m *SqlSelect, depth int) *SqlSelectPb {
 	s.Raw = m.Raw
 	s.Star = m.Star
 	s.Distinct = m.Distinct
+	s.Rollup = m.Rollup
 	s.Limit = int32(m.Limit)
 	s.Offset = int32(m.Offset)
 	s.IsAgg = m.isAgg
@@ -1005,6 +1007,9 @@ func (m *SqlSelect) Equal(ss SqlStatement) bool {
 	if m.Distinct != s.Distinct {
 		return false
 	}
+	if m.Rollup != s.Rollup {
+		return false
+	}
 	if m.Limit != s.Limit {
 		return false
 	}
@@ -1078,6 +1083,7 @@ func SqlSelectFromPb(pb *SqlSelectPb) *SqlSelect {
 		Raw:       pb.GetRaw(),
 		Star:      pb.GetStar(),
 		Distinct:  pb.GetDistinct(),
+		Rollup:    pb.GetRollup(),
 		Alias:     pb.GetAlias(),
 		Limit:     int(pb.GetLimit()),
 		Offset:    int(pb.GetOffset()),
@@ -1164,6 +1170,9 @@ func (m *SqlSelect) writeDialectDepth(depth int, w expr.DialectWriter) {
 	if len(m.GroupBy) > 0 {
 		io.WriteString(w, " GROUP BY ")
 		m.GroupBy.WriteDialect(w)
+		if m.Rollup {
+			io.WriteString(w, " WITH ROLLUP")
+		}
 	}
 	if m.Having != nil {
 		io.WriteString(w, " HAVING ")
@@ -1391,6 +1400,10 @@ func (m *SqlSource) writeDialectDepth(depth int, w expr.DialectWriter) {
 
 	//   Jointype                Op
 	//  INNER JOIN orders AS o 	ON
+	if int(m.LeftOrRight) != 0 {
+		io.WriteString(w, strings.ToTitle(m.LeftOrRight.String())) // left/right/full
+		io.WriteString(w, " ")
+	}
 	if int(m.JoinType) != 0 {
 		io.WriteString(w, strings.ToTitle(m.JoinType.String())) // inner/outer
 		io.WriteString(w, " ")
@@ -1416,8 +1429,11 @@ func (m *SqlSource) writeDialectDepth(depth int, w expr.DialectWriter) {
 		w.WriteIdentity(m.Alias)
 	}
 
-	io.WriteString(w, " ")
-	io.WriteString(w, strings.ToTitle(m.Op.String()))
+	if int(m.Op) != 0 {
+		// CROSS JOIN has no ON
+		io.WriteString(w, " ")
+		io.WriteString(w, strings.ToTitle(m.Op.String()))
+	}
 
 	if m.JoinExpr != nil {
 		w.Write([]byte{' '})
@@ -93,6 +93,7 @@ type SqlSelectPb struct {
 	Finalized        bool           `protobuf:"varint,17,req,name=finalized" json:"finalized"`
 	Schemaqry        bool           `protobuf:"varint,18,req,name=schemaqry" json:"schemaqry"`
 	With             []byte         `protobuf:"bytes,19,opt,name=with" json:"with,omitempty"`
+	Rollup           bool           `protobuf:"varint,20,opt,name=rollup" json:"rollup"`
 	XXX_unrecognized []byte         `json:"-"`
 }
 
@@ -234,6 +235,13 @@ func (m *SqlSelectPb) GetWith() []byte {
 	return nil
 }
 
+func (m *SqlSelectPb) GetRollup() bool {
+	if m != nil {
+		return m.Rollup
+	}
+	return false
+}
+
 type SqlSourcePb struct {
 	Final            bool           `protobuf:"varint,1,opt,name=final" json:"final"`
 	AliasInner       *string        `protobuf:"bytes,2,opt,name=aliasInner" json:"aliasInner,omitempty"`
@@ -924,6 +932,16 @@ func (m *SqlSelectPb) MarshalTo(data []byte) (int, error) {
 		i = encodeVarintSql(data, i, uint64(len(m.With)))
 		i += copy(data[i:], m.With)
 	}
+	data[i] = 0xa0
+	i++
+	data[i] = 0x1
+	i++
+	if m.Rollup {
+		data[i] = 1
+	} else {
+		data[i] = 0
+	}
+	i++
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -1530,6 +1548,7 @@ func (m *SqlSelectPb) Size() (n int) {
 		l = len(m.With)
 		n += 2 + l + sovSql(uint64(l))
 	}
+	n += 3
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2451,6 +2470,26 @@ func (m *SqlSelectPb) Unmarshal(data []byte) error {
 				m.With = []byte{}
 			}
 			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rollup", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSql
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Rollup = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSql(data[iNdEx:])
@@ -4322,72 +4361,72 @@ var (
 )
 
 var fileDescriptorSql = []byte{
-	// 1067 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x0e, 0x25, 0x51, 0x96, 0x56, 0x8e, 0xed, 0x6c, 0x82, 0x62, 0x61, 0x14, 0xae, 0x41, 0x14,
-	0x81, 0xd1, 0x34, 0x52, 0x91, 0x1e, 0x7a, 0x8e, 0x83, 0xb6, 0x30, 0x0a, 0xa4, 0x8e, 0x5c, 0xa0,
-	0x67, 0x4a, 0x5c, 0x51, 0x8c, 0x29, 0xae, 0xbc, 0x24, 0xe5, 0x38, 0x4f, 0xd2, 0x4b, 0x81, 0x5e,
-	0x7b, 0xeb, 0x33, 0xf4, 0xe4, 0x63, 0x9f, 0xa0, 0xe8, 0x0f, 0xfa, 0x1e, 0x9d, 0x99, 0x25, 0x97,
-	0x6b, 0x47, 0x72, 0x72, 0x10, 0xc0, 0xfd, 0xe6, 0xdb, 0xdd, 0x99, 0xd9, 0x6f, 0x66, 0xc4, 0xfa,
-	0xf9, 0x45, 0x3a, 0x5c, 0x6a, 0x55, 0x28, 0xde, 0xd6, 0x32, 0xdd, 0x7f, 0x12, 0x27, 0xc5, 0xbc,
-	0x9c, 0x0c, 0xa7, 0x6a, 0x31, 0x0a, 0x75, 0x18, 0x45, 0x2a, 0x1b, 0x5d, 0xa4, 0x13, 0x9d, 0x44,
-	0xb1, 0x1c, 0xc9, 0x37, 0x4b, 0x3d, 0xca, 0x54, 0x24, 0xcd, 0x8e, 0xfd, 0xa7, 0x0e, 0x39, 0x56,
-	0xb1, 0x1a, 0x11, 0x3c, 0x29, 0x67, 0xb4, 0xa2, 0x05, 0x7d, 0x19, 0x7a, 0xf0, 0xab, 0xc7, 0x76,
-	0xce, 0x2e, 0xd2, 0xb3, 0x22, 0x2c, 0xe4, 0x42, 0x66, 0xc5, 0xe9, 0x84, 0x0f, 0x59, 0x37, 0x97,
-	0xa9, 0x9c, 0x16, 0xc2, 0x3b, 0xf4, 0x8e, 0x06, 0xcf, 0xf6, 0x86, 0xe0, 0xc4, 0x10, 0x49, 0x84,
-	0x9e, 0x4e, 0x8e, 0x3b, 0xd7, 0x7f, 0x7e, 0xe2, 0x8d, 0x2b, 0x16, 0xf1, 0x55, 0xa9, 0xa7, 0x52,
-	0xb4, 0x6e, 0xf1, 0x09, 0x75, 0xf8, 0xb4, 0xe6, 0x5f, 0x31, 0x06, 0x77, 0xbf, 0x86, 0xad, 0x89,
-	0xca, 0x44, 0x87, 0xf6, 0x3c, 0xa0, 0x3d, 0xa7, 0x16, 0xb6, 0x9b, 0x1c, 0x6a, 0xf0, 0x9b, 0xcf,
-	0x06, 0x8e, 0x1b, 0xfc, 0x11, 0x6b, 0x45, 0x13, 0x70, 0xb2, 0x75, 0xd4, 0x27, 0xf6, 0xbd, 0x31,
-	0xac, 0xf9, 0x47, 0xac, 0xad, 0xc3, 0x4b, 0xf0, 0xa5, 0x81, 0x11, 0xe0, 0x82, 0x75, 0xf2, 0x22,
-	0xd4, 0xa2, 0x0d, 0x86, 0x5e, 0x65, 0x20, 0x84, 0x1f, 0xb2, 0x5e, 0x94, 0xe4, 0x45, 0x92, 0x41,
-	0xc8, 0x1d, 0xc7, 0x6a, 0x51, 0xfe, 0x94, 0x6d, 0x4d, 0x55, 0x5a, 0x2e, 0xb2, 0x5c, 0xf8, 0x87,
-	0x6d, 0xf0, 0xf7, 0x3e, 0xf9, 0xfb, 0x82, 0x30, 0xeb, 0x6b, 0xcd, 0xe1, 0x9f, 0xb1, 0xce, 0x4c,
-	0xab, 0x85, 0xe8, 0x12, 0x77, 0x53, 0x3e, 0x88, 0x83, 0x6e, 0x25, 0x59, 0xa1, 0xc4, 0x16, 0xe4,
-	0xa1, 0x5f, 0x5b, 0x10, 0xe1, 0x4f, 0x98, 0x7f, 0x39, 0x97, 0x5a, 0x8a, 0x1e, 0xa5, 0x68, 0xb7,
-	0x3e, 0xe6, 0x47, 0x04, 0xed, 0x29, 0x86, 0x03, 0x57, 0x76, 0xe7, 0xe1, 0x2a, 0xc9, 0x62, 0xd1,
-	0x27, 0xf6, 0xf6, 0x10, 0x85, 0x31, 0x7c, 0x09, 0xc2, 0x68, 0x1e, 0xc0, 0x30, 0x30, 0x1a, 0xa5,
-	0x23, 0xa9, 0x8f, 0xaf, 0x04, 0xbb, 0x23, 0x9a, 0x8a, 0x83, 0xf4, 0x58, 0xab, 0x72, 0x09, 0xf4,
-	0xc1, 0x1d, 0xf4, 0x8a, 0xc3, 0xf7, 0x99, 0x9f, 0x26, 0x8b, 0xa4, 0x10, 0xdb, 0xe0, 0x88, 0x5f,
-	0xa5, 0xd2, 0x40, 0xfc, 0x63, 0xd6, 0x55, 0xb3, 0x59, 0x2e, 0x0b, 0x71, 0xdf, 0x31, 0x56, 0x18,
-	0xee, 0x0c, 0xd3, 0x24, 0xcc, 0xc5, 0x8e, 0x93, 0x0b, 0x03, 0xdd, 0x12, 0xcd, 0xee, 0x07, 0x8b,
-	0x06, 0x0f, 0x4d, 0xf2, 0xe7, 0x71, 0x2c, 0xf6, 0x9c, 0x97, 0x35, 0x10, 0x0f, 0x58, 0x7f, 0x96,
-	0x64, 0x70, 0xc1, 0x5b, 0x19, 0x89, 0x07, 0x8e, 0xbd, 0x81, 0x91, 0x93, 0x4f, 0xe7, 0x72, 0x11,
-	0x5e, 0xe8, 0x2b, 0xc1, 0x5d, 0x8e, 0x85, 0xf1, 0x0d, 0x2f, 0xa1, 0xea, 0xc4, 0x43, 0x70, 0x6b,
-	0xbb, 0x7e, 0x43, 0x44, 0x82, 0xdf, 0x3b, 0x46, 0xb2, 0xd5, 0xcb, 0xa3, 0x37, 0x74, 0x34, 0x95,
-	0x96, 0xf5, 0x86, 0x20, 0xfe, 0x29, 0x63, 0x14, 0xeb, 0x49, 0x96, 0x49, 0x4d, 0xb5, 0x54, 0xe7,
-	0xc0, 0xc1, 0x5d, 0x29, 0xb6, 0x3f, 0x40, 0x8a, 0x9f, 0xb3, 0x1e, 0x7c, 0x9e, 0x64, 0x91, 0x7c,
-	0x03, 0xda, 0x46, 0x3e, 0x23, 0xfe, 0x77, 0xab, 0x93, 0xac, 0xa8, 0x75, 0x5e, 0x33, 0xf8, 0x17,
-	0xac, 0xff, 0x5a, 0x25, 0x19, 0xaa, 0xa6, 0x56, 0xfa, 0x3a, 0x21, 0x35, 0x24, 0xa7, 0xf8, 0xbb,
-	0xef, 0x69, 0x16, 0xa6, 0xf8, 0xab, 0xea, 0x6c, 0xd4, 0xde, 0x54, 0x67, 0x16, 0x2e, 0x8c, 0xd6,
-	0x6b, 0x03, 0x21, 0x8d, 0x2a, 0xfa, 0x8e, 0xa9, 0x52, 0x05, 0x74, 0x00, 0xb5, 0x04, 0x11, 0xb7,
-	0xac, 0x96, 0x60, 0xcd, 0x1f, 0xb3, 0x41, 0x2a, 0x67, 0xc5, 0xf7, 0x7a, 0x9c, 0xc4, 0xf3, 0x02,
-	0x44, 0xdb, 0x98, 0x5d, 0x03, 0xd6, 0x3d, 0x06, 0xf2, 0xc3, 0xd5, 0x52, 0x82, 0x58, 0x1b, 0x92,
-	0x45, 0x21, 0x3a, 0xfa, 0xfe, 0x1a, 0x32, 0x40, 0x8a, 0x5d, 0x9f, 0x0e, 0xcb, 0xe1, 0xcf, 0x58,
-	0x2f, 0x2f, 0x27, 0xaf, 0x4a, 0x09, 0x5a, 0xd9, 0xb9, 0x33, 0x1f, 0x96, 0x87, 0x5e, 0xe4, 0x52,
-	0x9e, 0x87, 0x93, 0x54, 0x92, 0xae, 0x6d, 0xf7, 0xa9, 0xd1, 0xe0, 0x2d, 0x63, 0x4d, 0xd9, 0x57,
-	0x31, 0x7b, 0xb7, 0x62, 0xde, 0xdc, 0x84, 0xd7, 0xbf, 0xc3, 0x63, 0xd6, 0xa1, 0xa8, 0xda, 0x1b,
-	0xa3, 0xea, 0x20, 0x14, 0xfc, 0xec, 0xb1, 0x6d, 0xb7, 0xc2, 0x6e, 0x34, 0x4b, 0x6f, 0x6d, 0xb3,
-	0xb4, 0x1a, 0x6f, 0xb9, 0x15, 0x67, 0x34, 0xbe, 0x4f, 0x72, 0x7c, 0x09, 0xef, 0x6a, 0xe4, 0xdb,
-	0x1f, 0xdb, 0x35, 0xff, 0xb2, 0x51, 0xb6, 0x51, 0xea, 0x43, 0x8a, 0x61, 0x2c, 0xf3, 0x32, 0x2d,
-	0x36, 0xe8, 0x3b, 0xf8, 0x0f, 0xe6, 0xd7, 0x4d, 0xc6, 0xba, 0x1a, 0xf3, 0xea, 0xfb, 0x6b, 0x99,
-	0xb9, 0xd3, 0xc1, 0xc8, 0x0c, 0x5a, 0x13, 0x9c, 0x79, 0xaa, 0x72, 0x1a, 0x10, 0xb6, 0x35, 0x19,
-	0x0c, 0x7a, 0x71, 0xd7, 0xdc, 0x58, 0xcd, 0xab, 0xb5, 0x45, 0x57, 0x51, 0xec, 0xa4, 0xf1, 0x9d,
-	0xfb, 0xcd, 0xa4, 0x81, 0xb7, 0x03, 0x21, 0x77, 0xdd, 0x89, 0x05, 0x2a, 0x86, 0x16, 0xb3, 0x0a,
-	0xd3, 0x52, 0x92, 0x10, 0xb7, 0x9c, 0xdb, 0x1b, 0x38, 0x18, 0x31, 0x9f, 0x4a, 0x96, 0x73, 0xe6,
-	0x9d, 0xdf, 0x98, 0x79, 0xde, 0x39, 0x62, 0x2b, 0x0a, 0xa9, 0xde, 0xe8, 0xad, 0x82, 0x5f, 0x3a,
-	0xac, 0x67, 0x53, 0x02, 0x15, 0x61, 0xde, 0xfd, 0x55, 0xa9, 0x0a, 0x49, 0x89, 0xa9, 0xfb, 0x94,
-	0x6b, 0x40, 0x5e, 0x98, 0xd3, 0xe7, 0xf1, 0x55, 0x61, 0xa4, 0x64, 0x79, 0x8e, 0x01, 0x5b, 0x95,
-	0xd2, 0x49, 0x8c, 0x29, 0x7d, 0x9e, 0x93, 0x86, 0x6c, 0xab, 0x6a, 0x70, 0xcc, 0x03, 0x96, 0x1b,
-	0xa5, 0xcc, 0x8e, 0x36, 0x44, 0xf0, 0x89, 0x34, 0xd5, 0xa6, 0xef, 0x76, 0x7a, 0x82, 0xd0, 0x87,
-	0x65, 0xa8, 0xe1, 0xaf, 0x88, 0x69, 0x5a, 0x5d, 0x67, 0x50, 0xb8, 0x06, 0x6a, 0xec, 0xc4, 0xd8,
-	0x72, 0xe7, 0x0c, 0x41, 0x4d, 0xbc, 0xe6, 0x8c, 0x9e, 0x7b, 0x86, 0x63, 0x68, 0x78, 0xdf, 0x24,
-	0x32, 0x8d, 0x9c, 0x0e, 0x63, 0xf3, 0x42, 0x86, 0xea, 0xdd, 0x06, 0x4e, 0x03, 0xc2, 0x77, 0x3b,
-	0x40, 0xc1, 0x2e, 0xf0, 0x5f, 0x13, 0xcd, 0xba, 0x7e, 0xa3, 0x4d, 0x02, 0xd1, 0x43, 0x9a, 0xa1,
-	0xd4, 0x3a, 0x6c, 0x94, 0x04, 0x59, 0x8d, 0xec, 0xbc, 0xa3, 0x11, 0xe8, 0x90, 0x21, 0x8c, 0x2b,
-	0xb7, 0x15, 0x20, 0x60, 0x2b, 0x76, 0xef, 0xee, 0x8a, 0xe5, 0x47, 0xcc, 0xff, 0xb6, 0x0c, 0x35,
-	0x0e, 0xb4, 0x4d, 0x44, 0x3f, 0x46, 0x42, 0x70, 0xc6, 0x76, 0x5f, 0x80, 0xab, 0x61, 0x16, 0x39,
-	0x42, 0x31, 0x97, 0x78, 0xef, 0xb9, 0x64, 0x63, 0x1d, 0x1d, 0x3f, 0xba, 0xfe, 0xfb, 0xc0, 0xbb,
-	0xfe, 0xe7, 0xc0, 0xfb, 0x03, 0x7e, 0x7f, 0xc1, 0xef, 0xa7, 0x7f, 0x0f, 0xee, 0xfd, 0x1f, 0x00,
-	0x00, 0xff, 0xff, 0xa8, 0x40, 0xb5, 0x23, 0xd3, 0x0a, 0x00, 0x00,
+	// 1070 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x8c, 0x96, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0xc7, 0x77, 0x1c, 0x27, 0x4d, 0x26, 0xe9, 0xc7, 0xce, 0x56, 0xab, 0x51, 0x85, 0x82, 0x15,
+	0xa1, 0x2a, 0xda, 0xb2, 0x09, 0x2a, 0x17, 0x5c, 0x6f, 0x57, 0x80, 0x2a, 0xa4, 0xa5, 0x9b, 0x22,
+	0x71, 0xed, 0xc4, 0x13, 0xc7, 0x5b, 0xdb, 0x93, 0x8e, 0xc7, 0x6d, 0xb3, 0x4f, 0xc2, 0x0d, 0x12,
+	0xb7, 0xbc, 0x06, 0x57, 0xbd, 0x42, 0x3c, 0x01, 0x82, 0x22, 0xde, 0x03, 0xcd, 0xb1, 0x3d, 0x3e,
+	0x2d, 0x69, 0x76, 0xef, 0xe2, 0xdf, 0xf9, 0xcf, 0xd7, 0x99, 0xff, 0x39, 0x13, 0xda, 0xc9, 0x2e,
+	0xe3, 0xd1, 0x52, 0x49, 0x2d, 0x59, 0x43, 0x89, 0xf8, 0xe0, 0x28, 0x8c, 0xf4, 0x22, 0x9f, 0x8e,
+	0x66, 0x32, 0x19, 0xfb, 0xca, 0x0f, 0x02, 0x99, 0x8e, 0x2f, 0xe3, 0xa9, 0x8a, 0x82, 0x50, 0x8c,
+	0xc5, 0xcd, 0x52, 0x8d, 0x53, 0x19, 0x88, 0x62, 0xc4, 0xc1, 0x4b, 0x24, 0x0e, 0x65, 0x28, 0xc7,
+	0x80, 0xa7, 0xf9, 0x1c, 0xbe, 0xe0, 0x03, 0x7e, 0x15, 0xf2, 0xc1, 0xaf, 0x84, 0xee, 0x9c, 0x5f,
+	0xc6, 0xe7, 0xda, 0xd7, 0x22, 0x11, 0xa9, 0x3e, 0x9b, 0xb2, 0x11, 0x6d, 0x65, 0x22, 0x16, 0x33,
+	0xcd, 0x89, 0x47, 0x86, 0xdd, 0xe3, 0xbd, 0x91, 0x12, 0xf1, 0xc8, 0x88, 0x80, 0x9e, 0x4d, 0x4f,
+	0xdc, 0xdb, 0x3f, 0x3f, 0x25, 0x93, 0x52, 0x05, 0x7a, 0x99, 0xab, 0x99, 0xe0, 0xce, 0x03, 0x3d,
+	0x50, 0xa4, 0x87, 0x6f, 0xf6, 0x15, 0xa5, 0x4b, 0x25, 0xdf, 0x89, 0x99, 0x8e, 0x64, 0xca, 0x5d,
+	0x18, 0xf3, 0x14, 0xc6, 0x9c, 0x59, 0x6c, 0x07, 0x21, 0xe9, 0xe0, 0xf7, 0x26, 0xed, 0xa2, 0x6d,
+	0xb0, 0x7d, 0xea, 0x04, 0x53, 0x4e, 0x3c, 0x67, 0xd8, 0x01, 0xf5, 0x93, 0x89, 0x13, 0x4c, 0xd9,
+	0x73, 0xda, 0x50, 0xfe, 0x35, 0x77, 0x10, 0x36, 0x80, 0x71, 0xea, 0x66, 0xda, 0x57, 0xbc, 0xe1,
+	0x39, 0xc3, 0x76, 0x19, 0x00, 0xc2, 0x3c, 0xda, 0x0e, 0xa2, 0x4c, 0x47, 0xe9, 0x4c, 0x73, 0x17,
+	0x45, 0x2d, 0x65, 0x2f, 0xe9, 0xd6, 0x4c, 0xc6, 0x79, 0x92, 0x66, 0xbc, 0xe9, 0x35, 0x86, 0xdd,
+	0xe3, 0x6d, 0xd8, 0xef, 0x6b, 0x60, 0x76, 0xaf, 0x95, 0x86, 0xbd, 0xa0, 0xee, 0x5c, 0xc9, 0x84,
+	0xb7, 0xbc, 0xc6, 0x86, 0x7c, 0x80, 0xc6, 0x6c, 0x2b, 0x4a, 0xb5, 0xe4, 0x5b, 0x1e, 0x29, 0xf7,
+	0x4b, 0x26, 0x40, 0xd8, 0x11, 0x6d, 0x5e, 0x2f, 0x84, 0x12, 0xbc, 0x0d, 0x29, 0xda, 0xad, 0xa6,
+	0xf9, 0xd1, 0x40, 0x3b, 0x4b, 0xa1, 0x61, 0x2f, 0x68, 0x6b, 0xe1, 0x5f, 0x45, 0x69, 0xc8, 0x3b,
+	0xa0, 0xee, 0x8d, 0x8c, 0x31, 0x46, 0x6f, 0x64, 0x80, 0x2e, 0xa0, 0x50, 0x98, 0xd3, 0x48, 0x15,
+	0x08, 0x75, 0xb2, 0xe2, 0x74, 0xc3, 0x69, 0x4a, 0x8d, 0x91, 0x87, 0x4a, 0xe6, 0xcb, 0x93, 0x15,
+	0xef, 0x6e, 0x90, 0x97, 0x1a, 0x76, 0x40, 0x9b, 0x71, 0x94, 0x44, 0x9a, 0xf7, 0x3c, 0x32, 0x6c,
+	0x96, 0xa9, 0x2c, 0x10, 0xfb, 0x84, 0xb6, 0xe4, 0x7c, 0x9e, 0x09, 0xcd, 0xb7, 0x51, 0xb0, 0x64,
+	0x66, 0xa4, 0x1f, 0x47, 0x7e, 0xc6, 0x77, 0x50, 0x2e, 0x0a, 0xf4, 0xc0, 0x34, 0xbb, 0x1f, 0x6d,
+	0x1a, 0x33, 0x69, 0x94, 0xbd, 0x0a, 0x43, 0xbe, 0x87, 0x6e, 0xb6, 0x40, 0x6c, 0x40, 0x3b, 0xf3,
+	0x28, 0xf5, 0xe3, 0xe8, 0xbd, 0x08, 0xf8, 0x53, 0x14, 0xaf, 0xb1, 0xd1, 0x64, 0xb3, 0x85, 0x48,
+	0xfc, 0x4b, 0xb5, 0xe2, 0x0c, 0x6b, 0x2c, 0x36, 0x77, 0x78, 0x1d, 0xe9, 0x05, 0x7f, 0xe6, 0x91,
+	0x61, 0xaf, 0xba, 0x43, 0x43, 0xcc, 0x81, 0x95, 0x8c, 0xe3, 0x7c, 0xc9, 0xf7, 0x3d, 0x62, 0x87,
+	0x96, 0x6c, 0xf0, 0x9b, 0x4b, 0xbb, 0xc8, 0x17, 0x66, 0xaf, 0xb0, 0x30, 0x27, 0x48, 0x5c, 0x20,
+	0xf6, 0x19, 0xa5, 0x90, 0x89, 0xd3, 0x34, 0x15, 0x8a, 0x3b, 0x28, 0x43, 0x88, 0x63, 0xa3, 0x36,
+	0x3e, 0xc2, 0xa8, 0x9f, 0xd3, 0xf6, 0x4c, 0xc6, 0xa7, 0x69, 0x20, 0x6e, 0xb8, 0x0b, 0x7a, 0x0a,
+	0xfa, 0xef, 0xae, 0x4e, 0x53, 0x5d, 0x55, 0x41, 0xa5, 0x60, 0x5f, 0xd0, 0xce, 0x3b, 0x19, 0xa5,
+	0xc6, 0x53, 0x55, 0x1d, 0xac, 0xb3, 0x59, 0x2d, 0x42, 0xad, 0xa1, 0xf5, 0x81, 0x56, 0x02, 0xaa,
+	0xaa, 0x76, 0xeb, 0x5a, 0xa8, 0x6b, 0x37, 0xf5, 0x93, 0xa2, 0x12, 0xaa, 0x00, 0x90, 0xda, 0x33,
+	0x1d, 0x14, 0x2a, 0x90, 0xe9, 0x0f, 0x72, 0xc9, 0xa9, 0xe7, 0x58, 0xa7, 0x39, 0x72, 0xc9, 0x0e,
+	0x69, 0x37, 0x16, 0x73, 0xfd, 0xbd, 0x9a, 0x44, 0xe1, 0x42, 0xf3, 0x2e, 0x0a, 0xe3, 0x80, 0xe9,
+	0x0a, 0xe6, 0x20, 0x3f, 0xac, 0x96, 0x82, 0xf7, 0x90, 0xc8, 0x52, 0x36, 0x2a, 0x14, 0x5f, 0xdf,
+	0x2c, 0x15, 0xf8, 0x79, 0x7d, 0x3a, 0xac, 0x86, 0x1d, 0xd3, 0x76, 0x96, 0x4f, 0xdf, 0xe6, 0x42,
+	0xad, 0xf8, 0xce, 0xc6, 0x7c, 0x58, 0x9d, 0xd9, 0x45, 0x26, 0xc4, 0x85, 0x3f, 0x8d, 0x05, 0xdf,
+	0x45, 0xae, 0xb0, 0x74, 0xf0, 0x9e, 0xd2, 0xba, 0x29, 0x94, 0x67, 0x26, 0x0f, 0xce, 0xfc, 0x78,
+	0x8b, 0x5e, 0x7f, 0x0f, 0x87, 0xd4, 0x85, 0x53, 0x35, 0x1e, 0x3d, 0x95, 0x6b, 0xd0, 0xe0, 0x67,
+	0x42, 0x7b, 0xb8, 0xfe, 0xee, 0xb5, 0x52, 0xb2, 0xb6, 0x95, 0x5a, 0x8f, 0x3b, 0xb8, 0x1e, 0x01,
+	0xb1, 0x03, 0xb0, 0xe3, 0x1b, 0x3f, 0x11, 0x85, 0x7d, 0x3b, 0x13, 0xfb, 0xcd, 0xbe, 0xac, 0x9d,
+	0x5d, 0x38, 0xf5, 0x19, 0x9c, 0x61, 0x22, 0xb2, 0x3c, 0xd6, 0x8f, 0xf8, 0x7b, 0xf0, 0x2f, 0xa1,
+	0x3b, 0xf7, 0x15, 0xeb, 0x6a, 0x8c, 0x54, 0xeb, 0x57, 0x36, 0xc3, 0x6f, 0x07, 0x10, 0x53, 0xc7,
+	0x33, 0x19, 0x9f, 0xc9, 0x8c, 0x37, 0x50, 0x6a, 0x4b, 0xc6, 0x8e, 0x20, 0x9a, 0x27, 0xd5, 0x6b,
+	0xb6, 0xb6, 0xe8, 0x4a, 0x89, 0x7d, 0x87, 0x9a, 0x68, 0x7d, 0x20, 0xe6, 0xee, 0xfc, 0x8c, 0xb7,
+	0xf0, 0x7b, 0xe6, 0x67, 0xa6, 0x01, 0x5d, 0xf9, 0x71, 0x2e, 0xc0, 0x88, 0x5b, 0x68, 0xf5, 0x1a,
+	0x0f, 0xc6, 0xb4, 0x09, 0x25, 0xcb, 0x18, 0x25, 0x17, 0xf7, 0x5e, 0x44, 0x72, 0x61, 0xd8, 0x15,
+	0x77, 0xd0, 0x40, 0x72, 0x35, 0xf8, 0xc5, 0xa5, 0x6d, 0x9b, 0x92, 0x43, 0xda, 0x2d, 0xee, 0xfd,
+	0x6d, 0x2e, 0xb5, 0xe0, 0x04, 0x75, 0x31, 0x1c, 0x30, 0x3a, 0x3f, 0x83, 0x9f, 0x27, 0x2b, 0x5d,
+	0x58, 0xc9, 0xea, 0x50, 0xc0, 0xb4, 0x2a, 0xa9, 0xa2, 0xd0, 0xa4, 0xf4, 0x55, 0x06, 0x1e, 0xb2,
+	0xad, 0xaa, 0xe6, 0x26, 0x0f, 0xa6, 0xdc, 0xb8, 0x8b, 0xe2, 0x40, 0xcc, 0x15, 0x29, 0xa8, 0xcd,
+	0x26, 0x0a, 0x15, 0xc8, 0xec, 0x61, 0xe9, 0x2b, 0x91, 0xea, 0xa2, 0x69, 0xb5, 0xd0, 0x33, 0x82,
+	0x03, 0xd0, 0xf6, 0x41, 0xb1, 0x85, 0x5f, 0x21, 0x40, 0xf5, 0x79, 0x8b, 0x39, 0xda, 0x78, 0x0e,
+	0x14, 0xa8, 0x75, 0xdf, 0x44, 0x22, 0x0e, 0x50, 0x87, 0x21, 0x13, 0x1c, 0x28, 0xef, 0xad, 0xeb,
+	0x91, 0x7b, 0xf7, 0xd6, 0x37, 0x86, 0x4d, 0xcc, 0x7f, 0x2a, 0xde, 0xb3, 0x21, 0x32, 0xa9, 0xa0,
+	0xd9, 0x21, 0xbc, 0xb0, 0x7c, 0x1b, 0x45, 0x0b, 0x64, 0x3d, 0xb2, 0xf3, 0x3f, 0x8f, 0x3c, 0xa7,
+	0x0d, 0x3f, 0x0c, 0xef, 0xb5, 0x02, 0x03, 0x6c, 0xc5, 0xee, 0x6d, 0xae, 0x58, 0x36, 0xa4, 0xcd,
+	0x6f, 0x73, 0x5f, 0x99, 0xe7, 0xee, 0x31, 0x61, 0x33, 0x34, 0x82, 0xc1, 0x39, 0xdd, 0x7d, 0x2d,
+	0x93, 0xc4, 0x4f, 0x03, 0x64, 0x94, 0x62, 0x11, 0xf2, 0x81, 0x45, 0x1e, 0xad, 0xa3, 0x93, 0xfd,
+	0xdb, 0xbf, 0xfb, 0xe4, 0xf6, 0xae, 0x4f, 0xfe, 0xb8, 0xeb, 0x93, 0xbf, 0xee, 0xfa, 0xe4, 0xa7,
+	0x7f, 0xfa, 0x4f, 0xfe, 0x1b, 0x00, 0xe6, 0xf9, 0x43, 0x3a, 0xf1, 0x0a, 0x00, 0x00,
 }
@@ -86,6 +86,42 @@ func TestToSql(t *testing.T) {
 	}
 }
 
+// Writing a statement back out is normalized (keyword case, whitespace) so
+// writing, re-parsing, and writing again must produce the same sql.
+func TestToSqlIdempotent(t *testing.T) {
+	t.Parallel()
+	sqls := append([]string{
+		`select a,   b AS bee from t`,
+		`SELECT a FROM t WHERE a = 1 AND (b > 2 OR c LIKE "x%")`,
+		`select count(*) as ct, user_id FROM orders GROUP BY user_id ORDER BY ct DESC LIMIT 10`,
+		`SELECT DISTINCT email FROM users WHERE deleted_at IS NULL`,
+	}, sqlStrings...)
+	for _, sqlStrIn := range sqls {
+		sql1 := parseOrPanic(t, sqlStrIn).(*rel.SqlSelect).String()
+		sql2 := parseOrPanic(t, sql1).(*rel.SqlSelect).String()
+		assert.Equal(t, sql1, sql2, "should be idempotent %s", sqlStrIn)
+	}
+}
+
+// The type of a CAST or CONVERT, with its precision, is written back as is
+func TestToSqlCast(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		sql string
+		out string
+	}{
+		{`SELECT CAST(code AS VARCHAR(20)) FROM t`, `SELECT cast(code AS VARCHAR(20)) FROM t`},
+		{`SELECT CAST(price AS DECIMAL(10, 2)) AS p FROM t`, `SELECT cast(price AS DECIMAL(10,2)) AS p FROM t`},
+		{`SELECT CAST(a AS int) FROM t`, `SELECT cast(a AS int) FROM t`},
+		{`SELECT CONVERT(price, DECIMAL(10,2)) FROM t`, `SELECT convert(price, DECIMAL(10,2)) FROM t`},
+	} {
+		sql1 := parseOrPanic(t, tt.sql).(*rel.SqlSelect).String()
+		assert.Equal(t, tt.out, sql1)
+		sql2 := parseOrPanic(t, sql1).(*rel.SqlSelect).String()
+		assert.Equal(t, sql1, sql2, "should round trip %s", tt.sql)
+	}
+}
+
 /*
 func comparePb(t *testing.T, sl, sr SqlStatement) {
 	lb, err := sl.ToPB()